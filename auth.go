@@ -0,0 +1,27 @@
+package gors
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+)
+
+// SendAuthorized sends a copy of the request with token as bearer token,
+// leaving the request itself untouched, so one request can be sent for
+// several users concurrently. The body of the returned response is
+// buffered and needs no closing.
+func (r *Request) SendAuthorized(ctx context.Context, token string) (*http.Response, error) {
+	request := r.clone()
+	request.SetHeader("Authorization", "Bearer "+token)
+
+	body, res, err := request.sendBuffered(ctx)
+
+	if err != nil {
+		return res, err
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	return res, nil
+}
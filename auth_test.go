@@ -0,0 +1,50 @@
+package gors
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestSendAuthorized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer srv.Close()
+
+	template := NewClient(srv.URL).NewRequest(GET, "/me")
+	tokens := []string{"alice", "bob"}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+		token := tokens[i%2]
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			res, err := template.SendAuthorized(context.Background(), token)
+
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			body, _ := io.ReadAll(res.Body)
+
+			if string(body) != "Bearer "+token {
+				t.Errorf("got %q, want Bearer %s", body, token)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if _, ok := template.GetHeaders()["Authorization"]; ok {
+		t.Fatal("the template request was modified")
+	}
+}
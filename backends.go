@@ -0,0 +1,59 @@
+package gors
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync/atomic"
+)
+
+// SetBackends spreads requests over several base URLs in turn, e.g. the
+// replicas of a service. Every attempt, including retries, goes to the
+// next backend. BaseURL is set to the first one.
+func (c *Client) SetBackends(baseURLs ...string) {
+	if c.shared == nil {
+		c.shared = &sharedState{}
+	}
+
+	c.backends = append([]string(nil), baseURLs...)
+
+	if len(baseURLs) > 0 {
+		c.BaseURL = baseURLs[0]
+	}
+}
+
+func (c Client) nextBackend() string {
+	n := atomic.AddUint64(&c.shared.nextBackend, 1) - 1
+	return c.backends[n%uint64(len(c.backends))]
+}
+
+// PinBackend sends the request to the backend at index in the list given to
+// SetBackends, bypassing the rotation, e.g. for sticky sessions.
+func (r *Request) PinBackend(index int) error {
+	if index < 0 || index >= len(r.client.backends) {
+		return fmt.Errorf("backend index %d out of range", index)
+	}
+
+	r.baseURL = r.client.backends[index]
+	r.pinnedBackend = true
+
+	return nil
+}
+
+// PinHost is PinBackend for the backend with the given host, with or
+// without port.
+func (r *Request) PinHost(host string) error {
+	for i, backend := range r.client.backends {
+		u, err := url.Parse(backend)
+
+		if err != nil {
+			continue
+		}
+
+		if strings.EqualFold(u.Host, host) || strings.EqualFold(u.Hostname(), host) {
+			return r.PinBackend(i)
+		}
+	}
+
+	return fmt.Errorf("no backend with host %q", host)
+}
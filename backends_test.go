@@ -0,0 +1,92 @@
+package gors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newNamedServer(name string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(name))
+	}))
+}
+
+func TestSetBackends(t *testing.T) {
+	a, b := newNamedServer("a"), newNamedServer("b")
+	defer a.Close()
+	defer b.Close()
+
+	c := NewClient("")
+	c.SetBackends(a.URL, b.URL)
+
+	var got string
+
+	for i := 0; i < 4; i++ {
+		res, err := c.NewRequest(GET, "/").Send()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got += string(res.Body)
+	}
+
+	if got != "abab" {
+		t.Fatalf("backends were used in order %q, want abab", got)
+	}
+}
+
+func TestPinBackend(t *testing.T) {
+	a, b := newNamedServer("a"), newNamedServer("b")
+	defer a.Close()
+	defer b.Close()
+
+	c := NewClient("")
+	c.SetBackends(a.URL, b.URL)
+
+	var got string
+
+	for i := 0; i < 3; i++ {
+		r := c.NewRequest(GET, "/")
+
+		if err := r.PinHost(strings.TrimPrefix(b.URL, "http://")); err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := r.Send()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got += string(res.Body)
+	}
+
+	r := c.NewRequest(GET, "/")
+
+	if err := r.PinBackend(0); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := r.Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got += string(res.Body)
+
+	if got != "bbba" {
+		t.Fatalf("pinned requests went to %q, want bbba", got)
+	}
+
+	if err := c.NewRequest(GET, "/").PinBackend(5); err == nil {
+		t.Error("expected an error for an index out of range")
+	}
+
+	if err := c.NewRequest(GET, "/").PinHost("unknown.example.com"); err == nil {
+		t.Error("expected an error for an unknown host")
+	}
+}
@@ -0,0 +1,47 @@
+package gors
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SetBaggage sets the W3C baggage header from entries, for propagating
+// key/value pairs along a distributed trace. Values are percent-encoded as
+// the spec requires; entries are sorted by key.
+func (r *Request) SetBaggage(entries map[string]string) {
+	keys := make([]string, 0, len(entries))
+
+	for k := range entries {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	members := make([]string, 0, len(keys))
+
+	for _, k := range keys {
+		members = append(members, strings.TrimSpace(k)+"="+escapeBaggageValue(entries[k]))
+	}
+
+	r.SetHeader("baggage", strings.Join(members, ","))
+}
+
+// escapeBaggageValue percent-encodes everything outside the baggage-octet
+// range (controls, whitespace, '"', ',', ';' and '\') as well as '%'.
+func escapeBaggageValue(v string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+
+		if c > 0x20 && c < 0x7f && c != '"' && c != ',' && c != ';' && c != '\\' && c != '%' {
+			b.WriteByte(c)
+			continue
+		}
+
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,20 @@
+package gors
+
+import (
+	"testing"
+)
+
+func TestSetBaggage(t *testing.T) {
+	r := NewClient("http://example.com").NewRequest(GET, "/")
+	r.SetBaggage(map[string]string{
+		"userId": "alice bob",
+		"tenant": "é,1",
+		"plan":   "100%",
+	})
+
+	want := "plan=100%25,tenant=%C3%A9%2C1,userId=alice%20bob"
+
+	if got := r.CanonicalHeaders().Get("Baggage"); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
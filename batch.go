@@ -0,0 +1,133 @@
+package gors
+
+import (
+	"context"
+	"sync"
+)
+
+// SendBatched splits items into batches of at most batchSize, sends one
+// request per batch and concatenates the decoded JSON arrays they return.
+// build creates the request for a batch; when it is nil, each batch is sent
+// as the JSON body of a copy of r. Batches are sent one after another and
+// the first failure, including a non-2xx status, stops the process.
+func SendBatched[T, R any](r *Request, items []T, batchSize int, build func([]T) (*Request, error)) ([]R, error) {
+	return SendBatchedConcurrently[T, R](r, items, batchSize, 1, build)
+}
+
+// SendBatchedConcurrently is SendBatched with up to workers batches in
+// flight at once. Results keep the order of the items. The first failure
+// cancels the batches still in flight and is returned together with the
+// results of the batches before the first one that didn't succeed.
+func SendBatchedConcurrently[T, R any](r *Request, items []T, batchSize, workers int, build func([]T) (*Request, error)) ([]R, error) {
+	if batchSize <= 0 {
+		batchSize = len(items)
+	}
+
+	if workers <= 0 {
+		workers = 1
+	}
+
+	if build == nil {
+		build = func(batch []T) (*Request, error) {
+			request := r.clone()
+			err := request.SetJSONBody(batch)
+
+			return request, err
+		}
+	}
+
+	var batches [][]T
+
+	for start := 0; start < len(items); start += batchSize {
+		end := start + batchSize
+
+		if end > len(items) {
+			end = len(items)
+		}
+
+		batches = append(batches, items[start:end])
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		sem      = make(chan struct{}, workers)
+		out      = make([][]R, len(batches))
+		done     = make([]bool, len(batches))
+	)
+
+	for i, batch := range batches {
+		sem <- struct{}{}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+
+		go func(i int, batch []T) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results, err := sendBatch[T, R](ctx, batch, build)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+
+				return
+			}
+
+			out[i], done[i] = results, true
+		}(i, batch)
+	}
+
+	wg.Wait()
+
+	var results []R
+
+	for i := range batches {
+		if !done[i] {
+			break
+		}
+
+		results = append(results, out[i]...)
+	}
+
+	return results, firstErr
+}
+
+func sendBatch[T, R any](ctx context.Context, batch []T, build func([]T) (*Request, error)) ([]R, error) {
+	request, err := build(batch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, res, err := request.sendBuffered(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !isSuccess(res.StatusCode) {
+		return nil, newStatusError(res.StatusCode, body)
+	}
+
+	var results []R
+
+	if err := request.decodeJSON(body, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
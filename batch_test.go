@@ -0,0 +1,108 @@
+package gors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSendBatched(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		var batch []int
+		json.NewDecoder(r.Body).Decode(&batch)
+
+		for i := range batch {
+			batch[i] *= 10
+		}
+
+		json.NewEncoder(w).Encode(batch)
+	}))
+	defer srv.Close()
+
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	results, err := SendBatched[int, int](NewClient(srv.URL).NewRequest(POST, "/bulk"), items, 3, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 4 {
+		t.Fatalf("got %d requests, want 4", requests)
+	}
+
+	if got := fmt.Sprint(results); got != "[10 20 30 40 50 60 70 80 90 100]" {
+		t.Fatalf("got %s", got)
+	}
+}
+
+func TestSendBatchedConcurrently(t *testing.T) {
+	var inflight, peak int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inflight, 1)
+
+		for {
+			p := atomic.LoadInt32(&peak)
+
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+
+		time.Sleep(30 * time.Millisecond)
+		atomic.AddInt32(&inflight, -1)
+		io.Copy(w, r.Body)
+	}))
+	defer srv.Close()
+
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	results, err := SendBatchedConcurrently[int, int](NewClient(srv.URL).NewRequest(POST, "/bulk"), items, 3, 4, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(results) != fmt.Sprint(items) {
+		t.Fatalf("got %v, want the items in order", results)
+	}
+
+	if p := atomic.LoadInt32(&peak); p != 4 {
+		t.Fatalf("server saw %d batches at once, want 4", p)
+	}
+}
+
+func TestSendBatchedStopsOnError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []int
+		json.NewDecoder(r.Body).Decode(&batch)
+
+		if batch[0] == 4 {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		json.NewEncoder(w).Encode(batch)
+	}))
+	defer srv.Close()
+
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+	results, err := SendBatched[int, int](NewClient(srv.URL).NewRequest(POST, "/bulk"), items, 3, nil)
+
+	var statusErr *StatusError
+
+	if !errors.As(err, &statusErr) || statusErr.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("got %v, want a 413 *StatusError", err)
+	}
+
+	if fmt.Sprint(results) != "[1 2 3]" {
+		t.Fatalf("got %v, want the results of the first batch", results)
+	}
+}
@@ -0,0 +1,53 @@
+package gors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+)
+
+// SetBatchBody sets a multipart/mixed body with one application/http part
+// per sub-request, for batch endpoints that run several requests in one
+// call. Each part holds the sub-request as it would be sent, with its
+// headers and body; parts are numbered by Content-ID in order.
+func (r *Request) SetBatchBody(requests []*Request) error {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	for i, sub := range requests {
+		payload, err := sub.payload()
+
+		if err != nil {
+			return err
+		}
+
+		req, err := sub.newHTTPRequest(context.Background(), payload)
+
+		if err != nil {
+			return err
+		}
+
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type": {"application/http"},
+			"Content-ID":   {fmt.Sprintf("<%d>", i+1)},
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if err := req.Write(part); err != nil {
+			return err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return err
+	}
+
+	r.SetBodyWithType(buf.Bytes(), "multipart/mixed; boundary="+mw.Boundary())
+
+	return nil
+}
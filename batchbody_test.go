@@ -0,0 +1,83 @@
+package gors
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"testing"
+)
+
+func TestSetBatchBody(t *testing.T) {
+	c := NewClient("http://api.example.com/v1")
+
+	get := c.NewRequest(GET, "/items/1")
+	get.SetQuery("fields", "id")
+
+	create := c.NewRequest(POST, "/items")
+
+	if err := create.SetJSONBody(map[string]int{"n": 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	r := c.NewRequest(POST, "/batch")
+
+	if err := r.SetBatchBody([]*Request{get, create}); err != nil {
+		t.Fatal(err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(r.GetHeaders()["Content-Type"])
+
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("got Content-Type %q, %v", r.GetHeaders()["Content-Type"], err)
+	}
+
+	mr := multipart.NewReader(bytes.NewReader(r.Body), params["boundary"])
+
+	var requests []*http.Request
+	var bodies []string
+
+	for {
+		part, err := mr.NextPart()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if ct := part.Header.Get("Content-Type"); ct != "application/http" {
+			t.Fatalf("got part Content-Type %q, want application/http", ct)
+		}
+
+		req, err := http.ReadRequest(bufio.NewReader(part))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := io.ReadAll(req.Body)
+		requests = append(requests, req)
+		bodies = append(bodies, string(body))
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("got %d parts, want 2", len(requests))
+	}
+
+	if requests[0].Method != GET || requests[0].RequestURI != "/v1/items/1?fields=id" {
+		t.Errorf("first part: got %s %s", requests[0].Method, requests[0].RequestURI)
+	}
+
+	if requests[1].Method != POST || requests[1].RequestURI != "/v1/items" || requests[1].Header.Get("Content-Type") != "application/json" {
+		t.Errorf("second part: got %s %s %v", requests[1].Method, requests[1].RequestURI, requests[1].Header)
+	}
+
+	if bodies[1] != `{"n":1}` {
+		t.Errorf("second part: got body %q", bodies[1])
+	}
+}
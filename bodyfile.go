@@ -0,0 +1,49 @@
+package gors
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// SetBodyFile streams the file at path as the body, with its size as
+// Content-Length. The Content-Type is derived from the file extension, or
+// sniffed from the content when the extension is unknown. The file is
+// opened anew for every attempt and closed once it has been sent.
+func (r *Request) SetBodyFile(path string) error {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+
+	if err != nil {
+		return err
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(path))
+
+	if contentType == "" {
+		head := make([]byte, 512)
+		n, err := io.ReadFull(f, head)
+
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+
+		contentType = http.DetectContentType(head[:n])
+	}
+
+	r.SetBodyReaderWithGetBody(func() (io.ReadCloser, error) {
+		return os.Open(path)
+	}, info.Size())
+	r.SetHeader("Content-Type", contentType)
+
+	return nil
+}
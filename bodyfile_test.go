@@ -0,0 +1,55 @@
+package gors
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetBodyFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "%s|%d|%s", r.Header.Get("Content-Type"), r.ContentLength, body)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	c := NewClient(srv.URL)
+
+	for _, tt := range []struct {
+		name, content, want string
+	}{
+		{"data.json", `{"a":1}`, `application/json|7|{"a":1}`},
+		{"page", "<html><body>x</body></html>", "text/html; charset=utf-8|27|<html><body>x</body></html>"},
+	} {
+		path := filepath.Join(dir, tt.name)
+
+		if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		r := c.NewRequest(PUT, "/upload")
+
+		if err := r.SetBodyFile(path); err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := r.Send()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(res.Body) != tt.want {
+			t.Errorf("%s: server got %q, want %q", tt.name, res.Body, tt.want)
+		}
+	}
+
+	if err := c.NewRequest(PUT, "/").SetBodyFile(filepath.Join(dir, "missing")); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,44 @@
+package gors
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrBudgetExhausted = errors.New("time budget exhausted")
+
+type budgetKey struct{}
+
+type budget struct {
+	mu        sync.Mutex
+	remaining time.Duration
+}
+
+// WithBudget returns a context carrying a time budget shared by every
+// request sent with it. Each request may use at most what is left, and its
+// duration is deducted afterwards; once nothing is left, sending fails with
+// ErrBudgetExhausted without touching the network.
+func WithBudget(ctx context.Context, total time.Duration) context.Context {
+	return context.WithValue(ctx, budgetKey{}, &budget{remaining: total})
+}
+
+func budgetFrom(ctx context.Context) *budget {
+	b, _ := ctx.Value(budgetKey{}).(*budget)
+	return b
+}
+
+func (b *budget) left() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.remaining
+}
+
+func (b *budget) spend(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.remaining -= d
+}
@@ -0,0 +1,36 @@
+package gors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithBudget(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(60 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	ctx := WithBudget(context.Background(), 100*time.Millisecond)
+
+	if _, err := c.NewRequest(GET, "/first").SendWithCtx(ctx); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+
+	_, err := c.NewRequest(GET, "/second").SendWithCtx(ctx)
+
+	if err == nil {
+		t.Fatal("second call succeeded, want it to run out of budget")
+	}
+
+	_, err = c.NewRequest(GET, "/third").SendWithCtx(ctx)
+
+	if !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("got %v, want ErrBudgetExhausted", err)
+	}
+}
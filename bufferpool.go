@@ -0,0 +1,77 @@
+package gors
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// maxPooledBuffer keeps buffers grown by unusually large bodies out of the
+// pool.
+const maxPooledBuffer = 1 << 20
+
+var defaultBufferPool = &sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// SetBufferPool sets the pool of *bytes.Buffer used to read response bodies
+// in the buffered helpers. Reading into a pooled buffer and copying out the
+// result avoids growing a fresh buffer for every response. A pool without
+// a New func, or that hands out something else, gets fresh buffers when
+// it has none. By default a pool shared by all clients is used.
+func (c *Client) SetBufferPool(pool *sync.Pool) {
+	c.bufferPool = pool
+}
+
+func (r *Request) readBody(body io.Reader) ([]byte, error) {
+	pool := r.client.bufferPool
+
+	if pool == nil {
+		pool = defaultBufferPool
+	}
+
+	buf, ok := pool.Get().(*bytes.Buffer)
+
+	if ok {
+		buf.Reset()
+	} else {
+		buf = new(bytes.Buffer)
+	}
+
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			pool.Put(buf)
+		}
+	}()
+
+	limit := r.responseLimit()
+
+	if limit > 0 {
+		body = io.LimitReader(body, limit+1)
+	}
+
+	if _, err := buf.ReadFrom(body); err != nil {
+		return nil, err
+	}
+
+	if limit > 0 && int64(buf.Len()) > limit {
+		return nil, ErrResponseTooLarge
+	}
+
+	return append([]byte(nil), buf.Bytes()...), nil
+}
+
+// maxDrainBytes bounds how much of an unread body is discarded to let the
+// connection be reused; larger remainders are cheaper to drop with the
+// connection.
+const maxDrainBytes = 256 << 10
+
+// drainAndClose reads what is left of body, up to maxDrainBytes, and closes
+// it. The transport only returns a connection to the idle pool once its
+// body has been read to EOF.
+func drainAndClose(body io.ReadCloser) {
+	io.Copy(io.Discard, io.LimitReader(body, maxDrainBytes))
+	body.Close()
+}
@@ -0,0 +1,94 @@
+package gors
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestSetBufferPool(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("pooled"))
+	}))
+	defer srv.Close()
+
+	var gets int
+
+	pool := &sync.Pool{New: func() interface{} {
+		gets++
+		return new(bytes.Buffer)
+	}}
+
+	c := NewClient(srv.URL)
+	c.SetBufferPool(pool)
+
+	res, err := c.NewRequest(GET, "/").Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(res.Body) != "pooled" {
+		t.Fatalf("got %q, want pooled", res.Body)
+	}
+
+	if gets == 0 {
+		t.Fatal("the configured pool wasn't used")
+	}
+}
+
+// smallBody is the size of the bodies read by the buffer pool benchmarks.
+var smallBody = bytes.Repeat([]byte("x"), 4<<10)
+
+// BenchmarkReadBodyPooled reads many small bodies through the buffer pool.
+// Compare its allocations with BenchmarkReadBodyReadAll.
+func BenchmarkReadBodyPooled(b *testing.B) {
+	r := NewClient("http://example.com").NewRequest(GET, "/")
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := r.readBody(bytes.NewReader(smallBody)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadBodyReadAll(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := io.ReadAll(bytes.NewReader(smallBody)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestSetBufferPoolWithoutNew(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	}))
+	defer srv.Close()
+
+	foreign := &sync.Pool{}
+	foreign.Put("not a buffer")
+
+	for _, pool := range []*sync.Pool{{}, foreign} {
+		c := NewClient(srv.URL)
+		c.SetBufferPool(pool)
+
+		for i := 0; i < 2; i++ {
+			res, err := c.NewRequest(GET, "/").Send()
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(res.Body) != "hello" {
+				t.Fatalf("got %q, want hello", res.Body)
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package gors
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+type countingReader struct {
+	io.ReadCloser
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n += int64(n)
+
+	return n, err
+}
+
+// countedBody is the body handed out by every send path. It keeps hold of
+// the reader counting the bytes received on the wire, and ends the
+// request's in-flight period when closed.
+type countedBody struct {
+	io.ReadCloser
+	wire    *countingReader
+	release func()
+	once    sync.Once
+}
+
+func (b *countedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+
+	return err
+}
+
+// BytesRead returns how many bytes of the body of res have been received
+// so far, before decompression, with the same caveat as Response.BytesRead.
+// It is only known for responses returned by gors; for others it is -1.
+func BytesRead(res *http.Response) int64 {
+	if b, ok := res.Body.(*countedBody); ok {
+		return b.wire.n
+	}
+
+	return -1
+}
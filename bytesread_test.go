@@ -0,0 +1,74 @@
+package gors
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBytesRead(t *testing.T) {
+	payload := strings.Repeat("x", 12345)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(payload))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	res, err := c.NewRequest(GET, "/").Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.BytesRead() != int64(len(payload)) {
+		t.Fatalf("got %d bytes read, want %d", res.BytesRead(), len(payload))
+	}
+
+	streamed, err := c.NewRequest(GET, "/").Stream(func(body io.Reader) error {
+		_, err := io.Copy(io.Discard, body)
+		return err
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := BytesRead(streamed); n != int64(len(payload)) {
+		t.Fatalf("got %d bytes read while streaming, want %d", n, len(payload))
+	}
+}
+
+func TestBytesReadCompressed(t *testing.T) {
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	zw.Write([]byte(strings.Repeat("x", 12345)))
+	zw.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compressed.Bytes())
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.EnableGzipRequests()
+
+	res, err := c.NewRequest(GET, "/").Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.Body) != 12345 {
+		t.Fatalf("got %d decoded bytes, want 12345", len(res.Body))
+	}
+
+	if res.BytesRead() != int64(compressed.Len()) {
+		t.Fatalf("got %d bytes read, want the %d compressed bytes", res.BytesRead(), compressed.Len())
+	}
+}
@@ -0,0 +1,66 @@
+package gors
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"strings"
+	"unicode/utf8"
+)
+
+// SetCharsetReader makes the buffered helpers convert text, XML and JSON
+// response bodies to UTF-8 according to the charset parameter of the
+// Content-Type, e.g. for legacy APIs answering in ISO-8859-1. XML documents
+// that only declare their encoding in the XML declaration are converted
+// while decoding. fn returns a reader converting input from the named
+// charset, like xml.Decoder's CharsetReader; the gorscharset package
+// provides one for the charsets of the WHATWG Encoding Standard.
+func (c *Client) SetCharsetReader(fn func(charset string, input io.Reader) (io.Reader, error)) {
+	c.charsetReader = fn
+}
+
+func isTextual(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "text/") ||
+		strings.HasSuffix(mediaType, "/xml") || strings.HasSuffix(mediaType, "+xml") ||
+		strings.HasSuffix(mediaType, "/json") || strings.HasSuffix(mediaType, "+json")
+}
+
+// toUTF8 converts a textual body from the charset named in contentType.
+func (r *Request) toUTF8(contentType string, body []byte) ([]byte, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+
+	if err != nil || !isTextual(mediaType) {
+		return body, nil
+	}
+
+	charset := strings.ToLower(params["charset"])
+
+	if charset == "" || charset == "utf-8" || charset == "utf8" {
+		return body, nil
+	}
+
+	converted, err := r.client.charsetReader(charset, bytes.NewReader(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(converted)
+}
+
+// xmlCharsetReader converts XML declared in a non-UTF-8 encoding. A body
+// that is valid UTF-8 has already been converted by toUTF8 (or is plain
+// ASCII) and is passed through.
+func (r *Request) xmlCharsetReader(label string, input io.Reader) (io.Reader, error) {
+	data, err := io.ReadAll(input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if utf8.Valid(data) {
+		return bytes.NewReader(data), nil
+	}
+
+	return r.client.charsetReader(label, bytes.NewReader(data))
+}
@@ -0,0 +1,10 @@
+package gors
+
+// SetPayloadCipher sets functions that encrypt non-empty request bodies just
+// before they are sent and decrypt non-empty response bodies once they have
+// been read. Response decryption applies to the buffered helpers such as
+// Send and SendWithJSONResponse, not to streaming ones.
+func (c *Client) SetPayloadCipher(encrypt func([]byte) ([]byte, error), decrypt func([]byte) ([]byte, error)) {
+	c.encrypt = encrypt
+	c.decrypt = decrypt
+}
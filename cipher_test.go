@@ -0,0 +1,78 @@
+package gors
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// xorEncrypt is a toy cipher: a version byte followed by the XORed payload,
+// so encrypted bodies are longer than plain ones.
+func xorEncrypt(b []byte) ([]byte, error) {
+	out := []byte{'v'}
+
+	for _, c := range b {
+		out = append(out, c^0x5a)
+	}
+
+	return out, nil
+}
+
+func xorDecrypt(b []byte) ([]byte, error) {
+	if len(b) == 0 || b[0] != 'v' {
+		return nil, errors.New("not encrypted")
+	}
+
+	out := make([]byte, 0, len(b)-1)
+
+	for _, c := range b[1:] {
+		out = append(out, c^0x5a)
+	}
+
+	return out, nil
+}
+
+func TestSetPayloadCipher(t *testing.T) {
+	var received string
+	var length int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		plain, err := xorDecrypt(b)
+
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		received, length = string(plain), r.ContentLength
+		reply, _ := xorEncrypt([]byte("reply to " + string(plain)))
+		w.Write(reply)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetPayloadCipher(xorEncrypt, xorDecrypt)
+
+	r := c.NewRequest(POST, "/")
+	r.SetBody([]byte("secret"))
+
+	res, err := r.Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if received != "secret" {
+		t.Errorf("server decrypted %q, want secret", received)
+	}
+
+	if length != int64(len("secret")+1) {
+		t.Errorf("got Content-Length %d, want the encrypted length %d", length, len("secret")+1)
+	}
+
+	if string(res.Body) != "reply to secret" {
+		t.Errorf("got response %q", res.Body)
+	}
+}
@@ -0,0 +1,44 @@
+package gors
+
+import (
+	"context"
+	"net/http"
+)
+
+// Codec encodes request bodies and decodes response bodies in a format gors
+// doesn't support itself, such as msgpack or CBOR.
+type Codec interface {
+	ContentType() string
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+func (r *Request) SetCodecBody(c Codec, v interface{}) error {
+	body, err := c.Marshal(v)
+
+	if err != nil {
+		return err
+	}
+
+	r.SetBodyWithType(body, c.ContentType())
+
+	return nil
+}
+
+// SendWithCodecResponse decodes the response with c, going through the
+// same pipeline as SendWithJSONResponse: retries, size limits, response
+// body decoders and the error envelope all apply. An empty body leaves the
+// value untouched.
+func SendWithCodecResponse[T any](r *Request, c Codec) (T, *http.Response, error) {
+	var v T
+
+	body, res, err := r.sendBuffered(context.Background())
+
+	if err != nil || len(body) == 0 {
+		return v, res, err
+	}
+
+	err = c.Unmarshal(body, &v)
+
+	return v, res, err
+}
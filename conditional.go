@@ -0,0 +1,16 @@
+package gors
+
+import "net/http"
+
+// SetIfNoneMatchAny sends If-None-Match: *, so a PUT only creates the
+// resource if it doesn't exist yet. If it does, the server answers 412,
+// which IsPreconditionFailed detects.
+func (r *Request) SetIfNoneMatchAny() {
+	r.SetHeader("If-None-Match", "*")
+}
+
+// IsPreconditionFailed reports whether res is a 412 Precondition Failed,
+// i.e. a conditional request's precondition did not hold.
+func IsPreconditionFailed(res *http.Response) bool {
+	return res != nil && res.StatusCode == http.StatusPreconditionFailed
+}
@@ -0,0 +1,46 @@
+package gors
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetIfNoneMatchAny(t *testing.T) {
+	exists := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "*" {
+			t.Errorf("got If-None-Match %q, want *", r.Header.Get("If-None-Match"))
+		}
+
+		if exists {
+			w.WriteHeader(http.StatusPreconditionFailed)
+
+			return
+		}
+
+		exists = true
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	for i, want := range []bool{false, true} {
+		r := c.NewRequest(PUT, "/x")
+		r.SetIfNoneMatchAny()
+
+		res, err := r.do(context.Background())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res.Body.Close()
+
+		if got := IsPreconditionFailed(res); got != want {
+			t.Fatalf("attempt %d: got %v, want %v", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package gors
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Config gathers the common client settings so a client can be set up in
+// one go, e.g. from a configuration file. Zero fields are left unchanged.
+type Config struct {
+	BaseURL        string
+	DefaultHeaders map[string]string
+	Timeout        time.Duration
+	Retry          *RetryPolicy
+	TLS            *tls.Config
+
+	// Proxy is the URL of the proxy all requests go through.
+	// ProxyFromEnvironment uses HTTP_PROXY and friends instead; only one of
+	// them may be set.
+	Proxy                string
+	ProxyFromEnvironment bool
+
+	// UserAgent is sent as User-Agent header. It can't be combined with a
+	// User-Agent entry in DefaultHeaders.
+	UserAgent string
+}
+
+// Configure applies cfg to the client. If cfg is invalid an error is
+// returned and the client is left unchanged.
+func (c *Client) Configure(cfg Config) error {
+	if cfg.Timeout < 0 {
+		return fmt.Errorf("negative timeout %v", cfg.Timeout)
+	}
+
+	if cfg.Proxy != "" && cfg.ProxyFromEnvironment {
+		return errors.New("Proxy and ProxyFromEnvironment are mutually exclusive")
+	}
+
+	if cfg.UserAgent != "" {
+		for k := range cfg.DefaultHeaders {
+			if strings.EqualFold(k, "User-Agent") {
+				return errors.New("UserAgent and a User-Agent default header are mutually exclusive")
+			}
+		}
+	}
+
+	if cfg.BaseURL != "" {
+		if _, err := url.Parse(cfg.BaseURL); err != nil {
+			return err
+		}
+	}
+
+	var proxy *url.URL
+
+	if cfg.Proxy != "" {
+		u, err := url.Parse(cfg.Proxy)
+
+		if err != nil {
+			return err
+		}
+
+		proxy = u
+	}
+
+	if cfg.BaseURL != "" {
+		c.BaseURL = cfg.BaseURL
+	}
+
+	if cfg.DefaultHeaders != nil || cfg.UserAgent != "" {
+		headers := copyMap(cfg.DefaultHeaders)
+
+		if cfg.DefaultHeaders == nil {
+			headers = copyMap(c.DefaultHeaders)
+		}
+
+		if cfg.UserAgent != "" {
+			for k := range headers {
+				if strings.EqualFold(k, "User-Agent") {
+					delete(headers, k)
+				}
+			}
+
+			headers["User-Agent"] = cfg.UserAgent
+		}
+
+		c.DefaultHeaders = headers
+	}
+
+	if cfg.Timeout > 0 {
+		c.SetDefaultTimeout(cfg.Timeout)
+	}
+
+	if cfg.Retry != nil {
+		c.SetRetryPolicy(*cfg.Retry)
+	}
+
+	if cfg.TLS != nil {
+		c.ensureTransport().TLSClientConfig = cfg.TLS.Clone()
+	}
+
+	if proxy != nil {
+		c.ensureTransport().Proxy = http.ProxyURL(proxy)
+	} else if cfg.ProxyFromEnvironment {
+		c.ensureTransport().Proxy = http.ProxyFromEnvironment
+	}
+
+	return nil
+}
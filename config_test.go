@@ -0,0 +1,70 @@
+package gors
+
+import (
+	"crypto/tls"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestConfigure(t *testing.T) {
+	c := NewClient("")
+
+	err := c.Configure(Config{
+		BaseURL:        "http://api.example.com",
+		DefaultHeaders: map[string]string{"Accept": "application/json"},
+		Timeout:        3 * time.Second,
+		Retry:          &RetryPolicy{MaxRetries: 2},
+		TLS:            &tls.Config{ServerName: "api.internal"},
+		Proxy:          "http://proxy:8080",
+		UserAgent:      "gors-test",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.BaseURL != "http://api.example.com" {
+		t.Errorf("got BaseURL %q", c.BaseURL)
+	}
+
+	if c.DefaultHeaders["Accept"] != "application/json" || c.DefaultHeaders["User-Agent"] != "gors-test" {
+		t.Errorf("got default headers %v", c.DefaultHeaders)
+	}
+
+	if c.NewRequest(GET, "/").Timeout != 3*time.Second {
+		t.Errorf("got timeout %v, want 3s", c.NewRequest(GET, "/").Timeout)
+	}
+
+	if c.retry.MaxRetries != 2 {
+		t.Errorf("got MaxRetries %d, want 2", c.retry.MaxRetries)
+	}
+
+	if c.transport.TLSClientConfig.ServerName != "api.internal" {
+		t.Errorf("got TLS server name %q", c.transport.TLSClientConfig.ServerName)
+	}
+
+	proxy, err := c.transport.Proxy(httptest.NewRequest(GET, "http://api.example.com/", nil))
+
+	if err != nil || proxy.Host != "proxy:8080" {
+		t.Errorf("got proxy %v, %v", proxy, err)
+	}
+}
+
+func TestConfigureRejectsConflicts(t *testing.T) {
+	for name, cfg := range map[string]Config{
+		"proxies":    {Proxy: "http://proxy", ProxyFromEnvironment: true},
+		"user agent": {UserAgent: "a", DefaultHeaders: map[string]string{"user-agent": "b"}},
+		"timeout":    {Timeout: -time.Second},
+	} {
+		c := NewClient("http://example.com")
+
+		if err := c.Configure(cfg); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+
+		if c.BaseURL != "http://example.com" || c.transport != nil {
+			t.Errorf("%s: an invalid config changed the client", name)
+		}
+	}
+}
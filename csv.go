@@ -0,0 +1,46 @@
+package gors
+
+import (
+	"encoding/csv"
+	"io"
+	"net/http"
+)
+
+// SetCSVDelimiter sets the field delimiter StreamCSV expects, instead of a
+// comma.
+func (r *Request) SetCSVDelimiter(delimiter rune) {
+	r.csvDelimiter = delimiter
+}
+
+// StreamCSV asks for a CSV response and calls fn for each record as it is
+// read, without holding the whole file in memory. Returning an error from
+// fn stops the stream.
+func StreamCSV(r *Request, fn func(record []string) error) (*http.Response, error) {
+	if r.header("Accept") == "" {
+		r.SetHeader("Accept", "text/csv")
+	}
+
+	return r.Stream(func(body io.Reader) error {
+		cr := csv.NewReader(body)
+
+		if r.csvDelimiter != 0 {
+			cr.Comma = r.csvDelimiter
+		}
+
+		for {
+			record, err := cr.Read()
+
+			if err == io.EOF {
+				return nil
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if err := fn(record); err != nil {
+				return err
+			}
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package gors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStreamCSV(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/csv" {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+
+		w.Write([]byte("id;name\n1;\"a;b\"\n2;c\n"))
+	}))
+	defer srv.Close()
+
+	r := NewClient(srv.URL).NewRequest(GET, "/export")
+	r.SetCSVDelimiter(';')
+
+	var records [][]string
+
+	_, err := StreamCSV(r, func(record []string) error {
+		records = append(records, record)
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := fmt.Sprintf("%q", records); got != `[["id" "name"] ["1" "a;b"] ["2" "c"]]` {
+		t.Fatalf("got %s", got)
+	}
+}
+
+func TestStreamCSVStopsOnError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("a\nb\nc\n"))
+	}))
+	defer srv.Close()
+
+	stop := errors.New("stop")
+	var seen int
+
+	_, err := StreamCSV(NewClient(srv.URL).NewRequest(GET, "/"), func(record []string) error {
+		seen++
+
+		if record[0] == "b" {
+			return stop
+		}
+
+		return nil
+	})
+
+	if !errors.Is(err, stop) || seen != 2 {
+		t.Fatalf("got %v after %d records, want the callback's error after 2", err, seen)
+	}
+}
@@ -0,0 +1,193 @@
+package gors
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// RequestFromCurl builds a request from a curl command, e.g. one copied
+// from API documentation or produced by AsCurl. Only -X/--request,
+// -H/--header, -d/--data/--data-raw/--data-binary, --url and the URL are
+// understood, plus a few flags without effect on the request such as -s,
+// -v, -L and --compressed. A relative URL is resolved against the client's
+// base URL.
+func RequestFromCurl(c Client, curlCmd string) (*Request, error) {
+	args, err := shellSplit(curlCmd)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) == 0 || args[0] != "curl" {
+		return nil, errors.New("not a curl command")
+	}
+
+	var method, rawURL string
+	var headers [][2]string
+	var data []string
+
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		name, value, inline := arg, "", false
+
+		if strings.HasPrefix(arg, "--") {
+			if eq := strings.IndexByte(arg, '='); eq > 0 {
+				name, value, inline = arg[:eq], arg[eq+1:], true
+			}
+		} else if len(arg) > 2 && arg[0] == '-' && strings.ContainsRune("XHd", rune(arg[1])) {
+			name, value, inline = arg[:2], arg[2:], true
+		}
+
+		switch name {
+		case "-s", "--silent", "-v", "--verbose", "-L", "--location", "-i", "--include", "--compressed", "-k", "--insecure":
+			continue
+		case "-X", "--request", "-H", "--header", "-d", "--data", "--data-raw", "--data-binary", "--data-ascii", "--url":
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return nil, fmt.Errorf("unsupported curl option %q", arg)
+			}
+
+			if rawURL != "" {
+				return nil, fmt.Errorf("more than one URL in curl command: %q", arg)
+			}
+
+			rawURL = arg
+			continue
+		}
+
+		if !inline {
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("curl option %s needs a value", name)
+			}
+
+			i++
+			value = args[i]
+		}
+
+		switch name {
+		case "-X", "--request":
+			method = strings.ToUpper(value)
+		case "-H", "--header":
+			k, v, ok := strings.Cut(value, ":")
+
+			if !ok {
+				return nil, fmt.Errorf("malformed curl header %q", value)
+			}
+
+			headers = append(headers, [2]string{strings.TrimSpace(k), strings.TrimSpace(v)})
+		case "--url":
+			rawURL = value
+		default:
+			data = append(data, value)
+		}
+	}
+
+	if rawURL == "" {
+		return nil, errors.New("curl command without URL")
+	}
+
+	u, err := url.Parse(rawURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if method == "" {
+		method = GET
+
+		if len(data) > 0 {
+			method = POST
+		}
+	}
+
+	r := c.NewRequest(method, u.Path)
+
+	if u.IsAbs() {
+		r.baseURL = u.Scheme + "://" + u.Host
+	}
+
+	for k, values := range u.Query() {
+		r.SetQuery(k, values[len(values)-1])
+	}
+
+	for _, h := range headers {
+		r.SetHeader(h[0], h[1])
+	}
+
+	if len(data) > 0 {
+		r.SetBody([]byte(strings.Join(data, "&")))
+		r.SetHeaderIfAbsent("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	return r, nil
+}
+
+// shellSplit splits a command line into words the way a POSIX shell does
+// for single and double quotes and backslashes. Line continuations are
+// dropped.
+func shellSplit(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+
+		switch {
+		case ch == '\\' && i+1 < len(s):
+			i++
+
+			if s[i] != '\n' {
+				word.WriteByte(s[i])
+				inWord = true
+			}
+		case ch == '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+
+			if end < 0 {
+				return nil, errors.New("unterminated single quote")
+			}
+
+			word.WriteString(s[i+1 : i+1+end])
+			i += end + 1
+			inWord = true
+		case ch == '"':
+			i++
+
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) && strings.IndexByte("\"\\$`\n", s[i+1]) >= 0 {
+					i++
+
+					if s[i] == '\n' {
+						continue
+					}
+				}
+
+				word.WriteByte(s[i])
+			}
+
+			if i >= len(s) {
+				return nil, errors.New("unterminated double quote")
+			}
+
+			inWord = true
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteByte(ch)
+			inWord = true
+		}
+	}
+
+	if inWord {
+		words = append(words, word.String())
+	}
+
+	return words, nil
+}
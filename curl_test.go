@@ -0,0 +1,77 @@
+package gors
+
+import (
+	"testing"
+)
+
+func TestRequestFromCurl(t *testing.T) {
+	c := NewClient("http://example.com")
+
+	r, err := RequestFromCurl(c, `curl -X PUT 'https://api.example.com/v1/items?a=1' \
+  -H 'Content-Type: application/json' -H "X-Token: a\"b" \
+  --data '{"name":"it'\''s"}'`)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := r.BuildURL()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Method != PUT || u.String() != "https://api.example.com/v1/items?a=1" {
+		t.Errorf("got %s %s", r.Method, u)
+	}
+
+	h := r.GetHeaders()
+
+	if h["Content-Type"] != "application/json" || h["X-Token"] != `a"b` {
+		t.Errorf("got headers %v", h)
+	}
+
+	if string(r.Body) != `{"name":"it's"}` {
+		t.Errorf("got body %q", r.Body)
+	}
+}
+
+func TestRequestFromCurlRoundTrip(t *testing.T) {
+	c := NewClient("http://example.com")
+
+	orig := c.NewRequest(POST, "/items")
+
+	if err := orig.SetJSONBody(map[string]string{"name": "a b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd, err := orig.AsCurl()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := RequestFromCurl(c, cmd)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, _ := r.BuildURL()
+
+	if r.Method != POST || u.String() != "http://example.com/items" || string(r.Body) != string(orig.Body) {
+		t.Fatalf("got %s %s %q from %s", r.Method, u, r.Body, cmd)
+	}
+}
+
+func TestRequestFromCurlDataImpliesPost(t *testing.T) {
+	r, err := RequestFromCurl(NewClient(""), `curl http://example.com -d 'a=1'`)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Method != POST {
+		t.Fatalf("got method %s, want POST for a curl command with data", r.Method)
+	}
+}
@@ -0,0 +1,49 @@
+package gors
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PropagateDeadlineHeader makes the request tell the server how much time it
+// has left: when the context passed to SendWithCtx has a deadline, the
+// remaining time is sent in the named header. "grpc-timeout" uses the gRPC
+// encoding (e.g. "1500m"); any other header gets whole milliseconds.
+func (r *Request) PropagateDeadlineHeader(name string) {
+	r.deadlineHeader = name
+}
+
+func (r *Request) deadlineHeaderValue(ctx context.Context) (string, bool) {
+	deadline, ok := ctx.Deadline()
+
+	if r.deadlineHeader == "" || !ok {
+		return "", false
+	}
+
+	remaining := time.Until(deadline)
+
+	if timeout := r.timeout(); timeout > 0 && timeout < remaining {
+		remaining = timeout
+	}
+
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	if strings.EqualFold(r.deadlineHeader, "grpc-timeout") {
+		return grpcTimeout(remaining), true
+	}
+
+	return strconv.FormatInt(remaining.Milliseconds(), 10), true
+}
+
+// grpcTimeout encodes d as a gRPC timeout, which allows at most 8 digits.
+func grpcTimeout(d time.Duration) string {
+	if ms := d.Milliseconds(); ms < 1e8 {
+		return strconv.FormatInt(ms, 10) + "m"
+	}
+
+	return strconv.FormatInt(int64(d/time.Second), 10) + "S"
+}
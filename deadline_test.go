@@ -0,0 +1,121 @@
+package gors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPropagateDeadlineHeader(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("X-Request-Timeout")
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	r := NewClient(srv.URL).NewRequest(GET, "/")
+	r.PropagateDeadlineHeader("X-Request-Timeout")
+
+	if _, err := r.SendWithCtx(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	ms, err := strconv.Atoi(got)
+
+	if err != nil {
+		t.Fatalf("got header %q, want milliseconds", got)
+	}
+
+	if ms > 2000 || ms < 1500 {
+		t.Fatalf("got %dms left, want just under 2000", ms)
+	}
+}
+
+func TestPropagateDeadlineHeaderGRPC(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("grpc-timeout")
+	}))
+	defer srv.Close()
+
+	r := NewClient(srv.URL).NewRequest(GET, "/")
+	r.PropagateDeadlineHeader("grpc-timeout")
+
+	if _, err := r.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got != "" {
+		t.Fatalf("got grpc-timeout %q without a context deadline", got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := r.SendWithCtx(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasSuffix(got, "m") {
+		t.Fatalf("got grpc-timeout %q, want milliseconds", got)
+	}
+}
+
+func TestGRPCTimeout(t *testing.T) {
+	if got := grpcTimeout(1500 * time.Millisecond); got != "1500m" {
+		t.Errorf("got %q, want 1500m", got)
+	}
+
+	if got := grpcTimeout(48 * time.Hour); got != "172800S" {
+		t.Errorf("got %q, want 172800S", got)
+	}
+}
+
+func TestSendWithJSONResponseDeadline(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The headers and the start of the body arrive at once; the rest
+		// of the body is slow.
+		w.Write([]byte(`{"a":`))
+		w.(http.Flusher).Flush()
+
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+
+		w.Write([]byte(`1}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	start := time.Now()
+
+	_, _, err := SendWithJSONResponseDeadline[map[string]int](c.NewRequest(GET, "/"), time.Now().Add(50*time.Millisecond))
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Fatalf("the body read wasn't aborted at the deadline, took %v", elapsed)
+	}
+
+	v, _, err := SendWithJSONResponseDeadline[map[string]int](c.NewRequest(GET, "/"), time.Now().Add(2*time.Second))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v["a"] != 1 {
+		t.Fatalf("got %v", v)
+	}
+}
@@ -0,0 +1,199 @@
+package gors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"sort"
+	"strings"
+)
+
+// SetLogger sets where debug output goes. It defaults to os.Stderr.
+func (c *Client) SetLogger(w io.Writer) {
+	c.logger = w
+}
+
+// SetDebug makes this request write a dump of itself and of its response to
+// the client's logger, without turning on logging for every request.
+func (r *Request) SetDebug(debug bool) {
+	r.debug = debug
+}
+
+func (r *Request) logWriter() io.Writer {
+	if r.client.logger != nil {
+		return r.client.logger
+	}
+
+	return os.Stderr
+}
+
+func (r *Request) logRequest(req *http.Request) {
+	dump, err := httputil.DumpRequestOut(req, r.bodyStream == nil)
+
+	if err != nil {
+		fmt.Fprintf(r.logWriter(), "gors: dumping request: %v\n", err)
+		return
+	}
+
+	if r.operationName != "" {
+		fmt.Fprintf(r.logWriter(), "gors: operation %s\n", r.operationName)
+	}
+
+	fmt.Fprintf(r.logWriter(), "%s\n\n", dump)
+}
+
+func (r *Request) logResponse(res *http.Response) {
+	dump, err := httputil.DumpResponse(res, true)
+
+	if err != nil {
+		fmt.Fprintf(r.logWriter(), "gors: dumping response: %v\n", err)
+		return
+	}
+
+	fmt.Fprintf(r.logWriter(), "%s\n\n", dump)
+}
+
+// Dump returns the request as it would be written on the wire. Streamed
+// bodies are left out since they can only be read once.
+func (r *Request) Dump() (string, error) {
+	var payload io.Reader
+
+	if r.bodyStream == nil {
+		body, err := r.finalBody()
+
+		if err != nil {
+			return "", err
+		}
+
+		payload = bytes.NewReader(body)
+	}
+
+	req, err := r.newHTTPRequest(context.Background(), payload)
+
+	if err != nil {
+		return "", err
+	}
+
+	dump, err := httputil.DumpRequestOut(req, r.bodyStream == nil)
+
+	return string(dump), err
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// EffectiveHeaders returns the headers the request would be sent with if it
+// were sent now: defaults, dynamic defaults, the request's own headers and
+// those gors derives from its settings, plus net/http's default
+// User-Agent. Headers added by middleware are not included, and since the
+// body transformer and cipher aren't run, a Content-Digest covers the body
+// as set. It returns nil if the request can't be built, e.g. because its
+// URL is invalid. This is meant for tests asserting on the final header
+// set.
+func (r *Request) EffectiveHeaders() map[string]string {
+	var payload io.Reader
+
+	if r.bodyStream == nil {
+		payload = bytes.NewReader(r.Body)
+	}
+
+	req, err := r.newHTTPRequest(context.Background(), payload)
+
+	if err != nil {
+		return nil
+	}
+
+	headers := make(map[string]string, len(req.Header)+1)
+
+	for k, v := range req.Header {
+		headers[k] = strings.Join(v, ", ")
+	}
+
+	if _, ok := req.Header["User-Agent"]; !ok {
+		headers["User-Agent"] = defaultUserAgent
+	}
+
+	return headers
+}
+
+// defaultUserAgent is what net/http sends when no User-Agent is set.
+const defaultUserAgent = "Go-http-client/1.1"
+
+// AsCurl returns an equivalent curl command, handy for reproducing a
+// request outside of Go.
+func (r *Request) AsCurl() (string, error) {
+	req, err := r.newHTTPRequest(context.Background(), nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	cmd := []string{"curl", "-X", r.Method, shellQuote(req.URL.String())}
+
+	keys := make([]string, 0, len(req.Header))
+
+	for k := range req.Header {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		for _, v := range req.Header[k] {
+			cmd = append(cmd, "-H", shellQuote(k+": "+v))
+		}
+	}
+
+	if r.bodyStream == nil {
+		body, err := r.finalBody()
+
+		if err != nil {
+			return "", err
+		}
+
+		if len(body) > 0 {
+			cmd = append(cmd, "--data-binary", shellQuote(string(body)))
+		}
+	}
+
+	return strings.Join(cmd, " "), nil
+}
+
+// EnableWireDebug writes every request and response to w as they go over
+// the wire, after all middleware has run.
+func (c *Client) EnableWireDebug(w io.Writer) {
+	c.wireDebug = w
+}
+
+type wireDebugTransport struct {
+	next http.RoundTripper
+	w    io.Writer
+}
+
+func (t wireDebugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if dump, err := httputil.DumpRequestOut(req, true); err == nil {
+		fmt.Fprintf(t.w, "%s\n\n", dump)
+	} else {
+		fmt.Fprintf(t.w, "gors: dumping request: %v\n", err)
+	}
+
+	res, err := t.next.RoundTrip(req)
+
+	if err != nil {
+		fmt.Fprintf(t.w, "gors: %v\n\n", err)
+		return nil, err
+	}
+
+	if dump, err := httputil.DumpResponse(res, true); err == nil {
+		fmt.Fprintf(t.w, "%s\n\n", dump)
+	} else {
+		fmt.Fprintf(t.w, "gors: dumping response: %v\n", err)
+	}
+
+	return res, nil
+}
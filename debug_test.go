@@ -0,0 +1,144 @@
+package gors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSetDebug(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("pong"))
+	}))
+	defer srv.Close()
+
+	var log strings.Builder
+
+	c := NewClient(srv.URL)
+	c.SetLogger(&log)
+
+	if _, err := c.NewRequest(GET, "/quiet").Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := c.NewRequest(POST, "/loud")
+	r.SetBody([]byte("ping"))
+	r.SetDebug(true)
+
+	res, err := r.Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(res.Body) != "pong" {
+		t.Fatalf("got body %q, want pong", res.Body)
+	}
+
+	out := log.String()
+
+	for _, want := range []string{"POST /loud", "ping", "200 OK", "pong"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("debug output is missing %q:\n%s", want, out)
+		}
+	}
+
+	if strings.Contains(out, "/quiet") {
+		t.Errorf("debug output includes a request without debug enabled:\n%s", out)
+	}
+}
+
+func TestEnableWireDebug(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("pong"))
+	}))
+	defer srv.Close()
+
+	var wire strings.Builder
+
+	c := NewClient(srv.URL)
+	c.Use("auth", func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("Authorization", "Bearer token")
+			return next.RoundTrip(req)
+		})
+	})
+	c.EnableWireDebug(&wire)
+
+	r := c.NewRequest(POST, "/wire")
+	r.SetBody([]byte("ping"))
+
+	res, err := r.Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(res.Body) != "pong" {
+		t.Fatalf("got body %q, want pong", res.Body)
+	}
+
+	out := wire.String()
+
+	for _, want := range []string{"POST /wire HTTP/1.1", "Authorization: Bearer token", "ping", "HTTP/1.1 200 OK", "pong"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("wire output is missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestEffectiveHeaders(t *testing.T) {
+	var sent http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = r.Header
+	}))
+	defer srv.Close()
+
+	var transforms int
+
+	c := NewClient(srv.URL)
+	c.SetDefaultHeaders(map[string]string{"X-Default": "1"})
+	c.AddDynamicDefaultHeader("X-Dynamic", func(*Request) string { return "d" })
+	c.SetBearerToken("token")
+	c.SetBodyTransformer(func(method, contentType string, body []byte) ([]byte, error) {
+		transforms++
+		return body, nil
+	})
+
+	r := c.NewRequest(POST, "/")
+
+	if err := r.SetJSONBody(1); err != nil {
+		t.Fatal(err)
+	}
+
+	h := r.EffectiveHeaders()
+
+	want := map[string]string{
+		"X-Default":     "1",
+		"X-Dynamic":     "d",
+		"Authorization": "Bearer token",
+		"User-Agent":    "Go-http-client/1.1",
+		"Content-Type":  "application/json",
+	}
+
+	for k, v := range want {
+		if h[k] != v {
+			t.Errorf("got %s %q, want %q", k, h[k], v)
+		}
+	}
+
+	if transforms != 0 {
+		t.Fatal("EffectiveHeaders ran the body transformer")
+	}
+
+	if _, err := r.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	for k, v := range h {
+		if sent.Get(k) != v {
+			t.Errorf("%s: sent %q, but EffectiveHeaders returned %q", k, sent.Get(k), v)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package gors
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+)
+
+// Format selects the decoder used by SendWithResponse.
+type Format int
+
+const (
+	FormatJSON Format = iota
+	FormatXML
+)
+
+// SetDefaultFormat sets the response format SendWithResponse decodes when a
+// request doesn't say otherwise. It defaults to FormatJSON.
+func (c *Client) SetDefaultFormat(f Format) {
+	c.format = f
+}
+
+func (r *Request) ExpectJSON() {
+	f := FormatJSON
+	r.expect = &f
+}
+
+func (r *Request) ExpectXML() {
+	f := FormatXML
+	r.expect = &f
+}
+
+func (r *Request) format() Format {
+	if r.expect != nil {
+		return *r.expect
+	}
+
+	return r.client.format
+}
+
+func (r *Request) decode(data []byte, v interface{}) error {
+	if r.format() == FormatXML {
+		if len(bytes.TrimSpace(data)) == 0 {
+			return nil
+		}
+
+		d := xml.NewDecoder(bytes.NewReader(data))
+
+		if r.client.charsetReader != nil {
+			d.CharsetReader = r.xmlCharsetReader
+		}
+
+		return d.Decode(v)
+	}
+
+	return r.decodeJSON(data, v)
+}
+
+// ResponseUnmarshaler is implemented by types that decode themselves from
+// the whole response, headers included. The body has already been read and
+// checked, and is handed over as a fresh reader.
+type ResponseUnmarshaler interface {
+	UnmarshalResponse(res *http.Response) error
+}
+
+// SendWithResponse sends the request and decodes the response with the
+// format the request expects, falling back to the client default.
+func SendWithResponse[T any](r *Request) (T, error) {
+	var v T
+
+	body, _, err := r.sendBuffered(context.Background())
+
+	if err != nil {
+		return v, err
+	}
+
+	err = r.decode(body, &v)
+
+	return v, err
+}
@@ -0,0 +1,118 @@
+package gors
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExpectFormatPerRequest(t *testing.T) {
+	type item struct {
+		Name string `json:"name" xml:"name"`
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/xml" {
+			w.Write([]byte(`<item><name>from xml</name></item>`))
+			return
+		}
+
+		w.Write([]byte(`{"name":"from json"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	xmlReq := c.NewRequest(GET, "/xml")
+	xmlReq.ExpectXML()
+
+	got, err := SendWithResponse[item](xmlReq)
+
+	if err != nil || got.Name != "from xml" {
+		t.Fatalf("xml: got %+v, %v", got, err)
+	}
+
+	jsonReq := c.NewRequest(GET, "/json")
+	jsonReq.ExpectJSON()
+
+	got, err = SendWithResponse[item](jsonReq)
+
+	if err != nil || got.Name != "from json" {
+		t.Fatalf("json: got %+v, %v", got, err)
+	}
+}
+
+func TestAddResponseBodyDecoder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/javascript")
+		w.Write([]byte(`callback({"a":2});`))
+	}))
+	defer srv.Close()
+
+	var order []string
+
+	c := NewClient(srv.URL)
+	c.AddResponseBodyDecoder(func(contentType string, body []byte) ([]byte, error) {
+		order = append(order, "jsonp")
+
+		if contentType != "application/javascript" {
+			return nil, errors.New("unexpected content type " + contentType)
+		}
+
+		body = bytes.TrimSuffix(bytes.TrimSpace(body), []byte(";"))
+		start := bytes.IndexByte(body, '(')
+
+		return body[start+1 : len(body)-1], nil
+	})
+	c.AddResponseBodyDecoder(func(contentType string, body []byte) ([]byte, error) {
+		order = append(order, "second")
+		return body, nil
+	})
+
+	v, err := SendWithJSONResponse[map[string]int](c.NewRequest(GET, "/"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v["a"] != 2 {
+		t.Fatalf("got %v", v)
+	}
+
+	if len(order) != 2 || order[0] != "jsonp" || order[1] != "second" {
+		t.Fatalf("decoders ran in order %v", order)
+	}
+}
+
+type taggedName struct {
+	ID, Name string
+}
+
+func (v *taggedName) UnmarshalResponse(res *http.Response) error {
+	v.ID = res.Header.Get("X-ID")
+	body, err := io.ReadAll(res.Body)
+	v.Name = string(body)
+
+	return err
+}
+
+func TestResponseUnmarshaler(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-ID", "42")
+		w.Write([]byte("bob"))
+	}))
+	defer srv.Close()
+
+	v, err := SendWithJSONResponse[taggedName](NewClient(srv.URL).NewRequest(GET, "/"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.ID != "42" || v.Name != "bob" {
+		t.Fatalf("got %+v, want ID 42 and name bob", v)
+	}
+}
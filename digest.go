@@ -0,0 +1,33 @@
+package gors
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net/http"
+)
+
+// SetContentDigest makes requests carry a Content-Digest header (RFC 9530)
+// with the SHA-256 of the body as sent, after the body transformer and
+// cipher have been applied. Streamed bodies are sent without it.
+func (c *Client) SetContentDigest(enable bool) {
+	c.contentDigest = enable
+}
+
+func contentDigest(req *http.Request) (string, error) {
+	body, err := req.GetBody()
+
+	if err != nil {
+		return "", err
+	}
+
+	defer body.Close()
+
+	h := sha256.New()
+
+	if _, err := io.Copy(h, body); err != nil {
+		return "", err
+	}
+
+	return "sha-256=:" + base64.StdEncoding.EncodeToString(h.Sum(nil)) + ":", nil
+}
@@ -0,0 +1,49 @@
+package gors
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetContentDigest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sum := sha256.Sum256(body)
+
+		if want := "sha-256=:" + base64.StdEncoding.EncodeToString(sum[:]) + ":"; r.Header.Get("Content-Digest") != want {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(r.Header.Get("Content-Digest")))
+			return
+		}
+
+		w.Write(body)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetContentDigest(true)
+	c.SetBodyTransformer(func(method, contentType string, body []byte) ([]byte, error) {
+		return append(body, '!'), nil
+	})
+
+	r := c.NewRequest(POST, "/")
+	r.SetBody([]byte("hello"))
+
+	res, err := r.Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("server rejected digest %q", res.Body)
+	}
+
+	if string(res.Body) != "hello!" {
+		t.Fatalf("got %q, want the transformed body", res.Body)
+	}
+}
@@ -0,0 +1,60 @@
+package gors
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// ErrClientClosed is returned for requests sent after Drain was called.
+var ErrClientClosed = errors.New("client is closed")
+
+// drainPollInterval is how often Drain checks for in-flight requests.
+const drainPollInterval = 10 * time.Millisecond
+
+// Drain stops the client from sending new requests, which fail with
+// ErrClientClosed, and waits until the requests in flight have finished,
+// i.e. their response bodies have been closed, or ctx is done. It is meant
+// for graceful shutdown and requires a client created with NewClient.
+func (c *Client) Drain(ctx context.Context) error {
+	if c.shared == nil {
+		c.shared = &sharedState{}
+	}
+
+	atomic.StoreInt32(&c.shared.closed, 1)
+
+	t := time.NewTicker(drainPollInterval)
+	defer t.Stop()
+
+	for atomic.LoadInt64(&c.shared.inFlight) > 0 {
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// enter registers a request as in flight. The returned function must be
+// called once it is done.
+func (c Client) enter() (func(), error) {
+	s := c.shared
+
+	if s == nil {
+		return func() {}, nil
+	}
+
+	// Counting before checking closed means Drain either sees this request
+	// or this request sees Drain.
+	atomic.AddInt64(&s.inFlight, 1)
+
+	if atomic.LoadInt32(&s.closed) == 1 {
+		atomic.AddInt64(&s.inFlight, -1)
+		return nil, ErrClientClosed
+	}
+
+	return func() { atomic.AddInt64(&s.inFlight, -1) }, nil
+}
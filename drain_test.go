@@ -0,0 +1,90 @@
+package gors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDrain(t *testing.T) {
+	var finished int32
+	started := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		atomic.StoreInt32(&finished, 1)
+		w.Write([]byte("done"))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	copied := c
+
+	result := make(chan string, 1)
+
+	go func() {
+		res, err := c.NewRequest(GET, "/").Send()
+
+		if err != nil {
+			result <- err.Error()
+			return
+		}
+
+		result <- string(res.Body)
+	}()
+
+	<-started
+
+	if err := c.Drain(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if atomic.LoadInt32(&finished) != 1 {
+		t.Fatal("Drain returned before the in-flight request finished")
+	}
+
+	if got := <-result; got != "done" {
+		t.Fatalf("in-flight request got %q, want done", got)
+	}
+
+	if _, err := copied.NewRequest(GET, "/").Send(); !errors.Is(err, ErrClientClosed) {
+		t.Fatalf("got %v after Drain, want ErrClientClosed", err)
+	}
+}
+
+func TestDrainTimeout(t *testing.T) {
+	started := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		c.NewRequest(GET, "/").Send()
+	}()
+
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := c.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+
+	srv.CloseClientConnections()
+	<-done
+}
@@ -0,0 +1,154 @@
+package gors
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Decompressor decodes a response body sent with a Content-Encoding.
+type Decompressor interface {
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+// DecompressorFunc adapts a function to Decompressor.
+type DecompressorFunc func(r io.Reader) (io.ReadCloser, error)
+
+func (f DecompressorFunc) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return f(r)
+}
+
+// ErrUnsupportedEncoding is returned for responses whose Content-Encoding
+// has no registered Decompressor. The error names the encoding.
+var ErrUnsupportedEncoding = errors.New("unsupported content encoding")
+
+var (
+	encodingsMu sync.RWMutex
+	encodings   = map[string]Decompressor{
+		"gzip": DecompressorFunc(func(r io.Reader) (io.ReadCloser, error) {
+			zr, err := gzip.NewReader(r)
+
+			if err != nil {
+				return nil, err
+			}
+
+			// Read all concatenated gzip members, as produced e.g. by
+			// servers appending compressed chunks. This is the default,
+			// but the body must never be cut short silently.
+			zr.Multistream(true)
+
+			return zr, nil
+		}),
+		"deflate": DecompressorFunc(zlib.NewReader),
+	}
+)
+
+// RegisterContentEncoding makes responses with the given Content-Encoding
+// decodable, so encodings like br or zstd can be supported without gors
+// depending on them. gzip and deflate are registered already.
+func RegisterContentEncoding(name string, d Decompressor) {
+	encodingsMu.Lock()
+	defer encodingsMu.Unlock()
+
+	encodings[strings.ToLower(name)] = d
+}
+
+func lookupEncoding(name string) (Decompressor, bool) {
+	encodingsMu.RLock()
+	defer encodingsMu.RUnlock()
+
+	if name == "x-gzip" {
+		name = "gzip"
+	}
+
+	d, ok := encodings[name]
+
+	return d, ok
+}
+
+// DisableDecompression returns response bodies exactly as received, without
+// decoding their Content-Encoding.
+func (c *Client) DisableDecompression() {
+	c.noDecompression = true
+}
+
+// EnableGzipRequests makes requests send Accept-Encoding: gzip explicitly.
+// Setting the header by hand turns off net/http's transparent decompression,
+// so gzip responses are decompressed by wrapBody instead.
+func (c *Client) EnableGzipRequests() {
+	c.gzip = true
+}
+
+// decodedBody creates the decompressing reader on the first Read, so an
+// empty body, which has no header for e.g. gzip to read, reads as empty
+// instead of failing.
+type decodedBody struct {
+	body io.ReadCloser
+	d    Decompressor
+	dr   io.ReadCloser
+	err  error
+}
+
+func (d *decodedBody) Read(p []byte) (int, error) {
+	if d.dr == nil && d.err == nil {
+		dr, err := d.d.NewReader(d.body)
+
+		if err != nil {
+			d.err = err
+		} else {
+			d.dr = dr
+		}
+	}
+
+	if d.err != nil {
+		return 0, d.err
+	}
+
+	return d.dr.Read(p)
+}
+
+func (d *decodedBody) Close() error {
+	if d.dr != nil {
+		d.dr.Close()
+	}
+
+	return d.body.Close()
+}
+
+// wrapBody returns the response body, decoded according to its
+// Content-Encoding. Every send path goes through it, so buffered and
+// streaming helpers see the same bytes. Responses that have no body, to
+// HEAD or with status 204 or 304, are left alone.
+func wrapBody(res *http.Response) (io.ReadCloser, error) {
+	encoding := strings.ToLower(strings.TrimSpace(res.Header.Get("Content-Encoding")))
+
+	if encoding == "" || encoding == "identity" || !hasBody(res) {
+		return res.Body, nil
+	}
+
+	d, ok := lookupEncoding(encoding)
+
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedEncoding, encoding)
+	}
+
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Content-Length")
+	res.ContentLength = -1
+	res.Uncompressed = true
+
+	return &decodedBody{body: res.Body, d: d}, nil
+}
+
+func hasBody(res *http.Response) bool {
+	if res.Request != nil && res.Request.Method == HEAD {
+		return false
+	}
+
+	return res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusNotModified && res.ContentLength != 0
+}
@@ -0,0 +1,171 @@
+package gors
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEnableGzipRequests(t *testing.T) {
+	var acceptEncoding string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte(`{"a":1}`))
+		zw.Close()
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.EnableGzipRequests()
+
+	res, err := c.NewRequest(GET, "/").Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if acceptEncoding != "gzip" {
+		t.Errorf("got Accept-Encoding %q, want gzip", acceptEncoding)
+	}
+
+	if string(res.Body) != `{"a":1}` {
+		t.Errorf("got body %q", res.Body)
+	}
+}
+
+// upperDecompressor stands in for a real zstd decoder: it "decompresses"
+// by upper-casing the body.
+var upperDecompressor = DecompressorFunc(func(r io.Reader) (io.ReadCloser, error) {
+	b, err := io.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(strings.NewReader(strings.ToUpper(string(b)))), nil
+})
+
+func TestRegisterContentEncoding(t *testing.T) {
+	RegisterContentEncoding("zstd", upperDecompressor)
+
+	defer func() {
+		encodingsMu.Lock()
+		delete(encodings, "zstd")
+		encodingsMu.Unlock()
+	}()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "zstd")
+		w.Write([]byte("hello"))
+	}))
+	defer srv.Close()
+
+	res, err := NewClient(srv.URL).NewRequest(GET, "/").Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(res.Body) != "HELLO" {
+		t.Fatalf("got %q, want HELLO", res.Body)
+	}
+}
+
+func TestEmptyCompressedBodies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+
+		switch {
+		case r.Method == DELETE:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/not-modified":
+			w.WriteHeader(http.StatusNotModified)
+		case r.URL.Path == "/chunked":
+			// Flushing before writing anything sends an empty chunked body.
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.EnableGzipRequests()
+
+	tests := []struct {
+		method, path string
+	}{
+		{HEAD, "/"},
+		{DELETE, "/"},
+		{GET, "/not-modified"},
+		{GET, "/"},
+		{GET, "/chunked"},
+	}
+
+	for _, tt := range tests {
+		if _, err := c.NewRequest(tt.method, tt.path).Send(); err != nil {
+			t.Errorf("%s %s: %v", tt.method, tt.path, err)
+		}
+	}
+
+	if ok, err := c.Exists("/"); !ok || err != nil {
+		t.Errorf("Exists: got %v, %v", ok, err)
+	}
+
+	if err := c.Ping(context.Background()); err != nil {
+		t.Errorf("Ping: %v", err)
+	}
+}
+
+func TestUnsupportedEncoding(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "zstd")
+		w.Write([]byte{0x28, 0xb5, 0x2f, 0xfd})
+	}))
+	defer srv.Close()
+
+	_, err := SendWithJSONResponse[map[string]int](NewClient(srv.URL).NewRequest(GET, "/"))
+
+	if !errors.Is(err, ErrUnsupportedEncoding) {
+		t.Fatalf("got %v, want ErrUnsupportedEncoding", err)
+	}
+
+	if !strings.Contains(err.Error(), "zstd") {
+		t.Fatalf("error %q doesn't name the encoding", err)
+	}
+}
+
+func TestMultiMemberGzip(t *testing.T) {
+	var body bytes.Buffer
+
+	for _, member := range []string{"hello ", "world"} {
+		zw := gzip.NewWriter(&body)
+		zw.Write([]byte(member))
+		zw.Close()
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(body.Bytes())
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.EnableGzipRequests()
+
+	res, err := c.NewRequest(GET, "/").Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(res.Body) != "hello world" {
+		t.Fatalf("got %q, want both gzip members", res.Body)
+	}
+}
@@ -0,0 +1,73 @@
+package gors
+
+import "context"
+
+// maxErrorBody caps how much of a response body a *StatusError keeps.
+const maxErrorBody = 1024
+
+func newStatusError(code int, body []byte) *StatusError {
+	if len(body) > maxErrorBody {
+		body = body[:maxErrorBody]
+	}
+
+	return &StatusError{Code: code, Body: append([]byte(nil), body...)}
+}
+
+// SetErrorEnvelope sets a function that turns the body of a non-2xx
+// response into an error, so error payloads are parsed in one place. The
+// buffered helpers such as Send and SendWithJSONResponse return that error;
+// if the function returns nil the response is handled as usual.
+func (c *Client) SetErrorEnvelope(fn func(body []byte) error) {
+	c.errorEnvelope = fn
+}
+
+// SetErrorFormatter overrides the client's error envelope for this request,
+// for an endpoint whose errors have an unusual shape.
+func (r *Request) SetErrorFormatter(fn func(status int, body []byte) error) {
+	r.errorFormatter = fn
+}
+
+func isSuccess(code int) bool {
+	return code >= 200 && code < 300
+}
+
+// responseError returns the error a non-2xx response should produce, if
+// any.
+func (r *Request) responseError(code int, body []byte) error {
+	if isSuccess(code) {
+		return nil
+	}
+
+	if r.errorFormatter != nil {
+		return r.errorFormatter(code, body)
+	}
+
+	if r.client.errorEnvelope == nil {
+		return nil
+	}
+
+	return r.client.errorEnvelope(body)
+}
+
+// SendOrError decodes the JSON body of a 2xx response. Any other status
+// gives a *StatusError holding the status and the start of the body.
+func SendOrError[T any](r *Request) (T, error) {
+	var v T
+
+	body, res, err := r.sendBuffered(context.Background())
+
+	if err != nil {
+		return v, err
+	}
+
+	if !isSuccess(res.StatusCode) {
+		return v, newStatusError(res.StatusCode, body)
+	}
+
+	if err := r.decodeJSON(body, &v); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return v, nil
+}
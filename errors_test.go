@@ -0,0 +1,110 @@
+package gors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSetErrorEnvelope(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message":"name is required"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetErrorEnvelope(func(body []byte) error {
+		var envelope struct{ Message string }
+
+		if err := json.Unmarshal(body, &envelope); err != nil {
+			return err
+		}
+
+		return errors.New(envelope.Message)
+	})
+
+	_, err := c.NewRequest(POST, "/users").Send()
+
+	if err == nil || err.Error() != "name is required" {
+		t.Fatalf("got %v, want the message from the envelope", err)
+	}
+}
+
+func TestSendOrError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("name") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"name is required"}` + strings.Repeat(" ", 2*maxErrorBody)))
+			return
+		}
+
+		w.Write([]byte(`{"name":"` + r.URL.Query().Get("name") + `"}`))
+	}))
+	defer srv.Close()
+
+	type user struct{ Name string }
+
+	c := NewClient(srv.URL)
+	r := c.NewRequest(GET, "/users")
+	r.SetQuery("name", "gors")
+
+	v, err := SendOrError[user](r)
+
+	if err != nil || v.Name != "gors" {
+		t.Fatalf("got %+v, %v", v, err)
+	}
+
+	v, err = SendOrError[user](c.NewRequest(GET, "/users"))
+
+	var statusErr *StatusError
+
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("got %v, want a *StatusError", err)
+	}
+
+	if v != (user{}) {
+		t.Fatalf("got %+v, want the zero value", v)
+	}
+
+	if statusErr.Code != http.StatusBadRequest || !strings.HasPrefix(string(statusErr.Body), `{"error":"name is required"}`) {
+		t.Fatalf("got status %d and body %q", statusErr.Code, statusErr.Body)
+	}
+
+	if len(statusErr.Body) != maxErrorBody {
+		t.Fatalf("got a %d byte body, want it capped at %d", len(statusErr.Body), maxErrorBody)
+	}
+}
+
+func TestSetErrorFormatter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"code":"E42","message":"odd shape"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetErrorEnvelope(func(body []byte) error {
+		return errors.New("client envelope")
+	})
+
+	r := c.NewRequest(GET, "/")
+	r.SetErrorFormatter(func(status int, body []byte) error {
+		var v struct{ Code string }
+		json.Unmarshal(body, &v)
+
+		return fmt.Errorf("%d %s", status, v.Code)
+	})
+
+	if _, err := r.Send(); err == nil || err.Error() != "422 E42" {
+		t.Fatalf("got %v, want the formatter's error", err)
+	}
+
+	if _, err := c.NewRequest(GET, "/").Send(); err == nil || err.Error() != "client envelope" {
+		t.Fatalf("got %v, want the client envelope for other requests", err)
+	}
+}
@@ -0,0 +1,15 @@
+package gors
+
+import "net/url"
+
+func (r *Request) SetFormBody(values url.Values) {
+	r.SetBodyWithType([]byte(values.Encode()), "application/x-www-form-urlencoded")
+}
+
+// PostForm returns a POST request with values as its form-encoded body.
+func (c Client) PostForm(path string, values url.Values) (*Request, error) {
+	request := c.NewRequest(POST, path)
+	request.SetFormBody(values)
+
+	return request, nil
+}
@@ -0,0 +1,36 @@
+package gors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestPostForm(t *testing.T) {
+	var form url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != POST {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		r.ParseForm()
+		form = r.PostForm
+	}))
+	defer srv.Close()
+
+	r, err := NewClient(srv.URL).PostForm("/login", url.Values{"user": {"gors"}, "tags": {"a", "b"}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if form.Get("user") != "gors" || len(form["tags"]) != 2 || form["tags"][1] != "b" {
+		t.Fatalf("server parsed %v", form)
+	}
+}
@@ -1,3 +1,11 @@
 module github.com/kmatsoukas/gors
 
 go 1.19
+
+require (
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+	golang.org/x/text v0.14.0
+	google.golang.org/protobuf v1.33.0
+)
+
+require github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
@@ -2,13 +2,17 @@ package gors
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"path"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -20,115 +24,888 @@ const (
 	HEAD    = "HEAD"
 	PATCH   = "PATCH"
 	OPTIONS = "OPTIONS"
+	TRACE   = "TRACE"
+	CONNECT = "CONNECT"
 )
 
 type Request struct {
 	baseURL string
+	client  Client
 	Method  string
 	Path    string
 	Query   map[string]string
 	Body    []byte
 	Headers map[string]string
+	Timeout time.Duration
+	expect  *Format
+
+	deadlineHeader string
+	bodyStream     func() (io.ReadCloser, error)
+
+	skipAllMiddleware    bool
+	skipMiddleware       map[string]bool
+	maxRedirects         *int
+	queryOrder           []string
+	debug                bool
+	json                 *jsonCodec
+	sni                  string
+	noContentCodes       []int
+	maxResponseBytes     *int64
+	noExpectContinue     bool
+	retryDecider         func(res *http.Response, err error) bool
+	bodyLength           int64
+	bodyReplayable       bool
+	noRetry              bool
+	errorFormatter       func(status int, body []byte) error
+	schema               jsonSchema
+	responseSchema       jsonSchema
+	rotateIdempotencyKey bool
+	pinnedBackend        bool
+	maxDownloadBytes     int64
+	latencySLA           time.Duration
+	lastLatency          time.Duration
+	operationName        string
+	csvDelimiter         rune
+	rateLimitBucket      string
+	clientCert           *tls.Certificate
+	extraHeaders         http.Header
 }
 
 type Response struct {
 	Code int
 	Body []byte
+
+	bytesRead int64
+}
+
+// BytesRead returns the number of bytes the response took on the wire,
+// before decompression. gzip responses that net/http decompressed
+// transparently, because no Accept-Encoding was set, count decompressed;
+// EnableGzipRequests avoids that.
+func (r Response) BytesRead() int64 {
+	return r.bytesRead
 }
 
+// Client holds the configuration requests are created from. Once set up it
+// may be used from several goroutines; of its methods that change it, only
+// AddDefaultHeader, SetBearerToken and Drain may be called concurrently
+// with NewRequest and sending.
 type Client struct {
 	BaseURL        string
 	DefaultHeaders map[string]string
+	stopStreamOn   []int
+	keyStyle       KeyStyle
+	gzip           bool
+	transport      *http.Transport
+	dynamicHeaders []dynamicHeader
+	bodyTransform  func(method, contentType string, body []byte) ([]byte, error)
+
+	bodyResetRetries   int
+	strictRedirects    bool
+	encrypt            func([]byte) ([]byte, error)
+	decrypt            func([]byte) ([]byte, error)
+	format             Format
+	headerCasing       func(string) string
+	middleware         []namedMiddleware
+	maxRedirects       *int
+	unsortedQuery      bool
+	attemptHook        func(attempt int, r *http.Request)
+	logger             io.Writer
+	errorEnvelope      func(body []byte) error
+	contentType        string
+	json               *jsonCodec
+	omitContentType    bool
+	timeout            *time.Duration
+	noDecompression    bool
+	partialDecode      bool
+	bufferPool         *sync.Pool
+	maxResponseBytes   int64
+	charsetReader      func(charset string, input io.Reader) (io.Reader, error)
+	retry              RetryPolicy
+	wireDebug          io.Writer
+	healthPath         string
+	customMethods      bool
+	zeroTimeoutDefault bool
+	shared             *sharedState
+	encodePathSegments bool
+	retryHeaders       [][2]string
+	contentDigest      bool
+	preserveMethod     bool
+	bodyDecoders       []func(contentType string, body []byte) ([]byte, error)
+	responseSchemas    bool
+	dialContext        func(ctx context.Context, network, addr string) (net.Conn, error)
+	backends           []string
+	metricsObserver    func(RequestMetrics)
+}
+
+type dynamicHeader struct {
+	key string
+	fn  func(*Request) string
+}
+
+// StatusError is returned when a response status prevents the response
+// from being processed. Body holds the start of the response body when it
+// was read.
+type StatusError struct {
+	Code int
+	Body []byte
+}
+
+func (e *StatusError) Error() string {
+	if len(e.Body) > 0 {
+		return fmt.Sprintf("unexpected response status %d: %s", e.Code, e.Body)
+	}
+
+	return fmt.Sprintf("unexpected response status %d", e.Code)
 }
 
 func (c *Client) SetDefaultHeaders(h map[string]string) {
 	c.DefaultHeaders = h
 }
 
+func copyMap(m map[string]string) map[string]string {
+	c := make(map[string]string, len(m))
+
+	for k, v := range m {
+		c[k] = v
+	}
+
+	return c
+}
+
+// GetDefaultHeaders returns a copy of the default headers; changing it does
+// not affect the client.
+func (c Client) GetDefaultHeaders() map[string]string {
+	return c.defaultHeaders()
+}
+
+// GetBaseURL returns the URL the client resolves request paths against.
+func (c Client) GetBaseURL() string {
+	return c.BaseURL
+}
+
+// StopStreamOn makes the streaming helpers abort and return a *StatusError
+// when the initial response has one of the given status codes.
+func (c *Client) StopStreamOn(codes ...int) {
+	c.stopStreamOn = codes
+}
+
+// AddDynamicDefaultHeader adds a default header whose value is computed by
+// fn each time a request is sent, such as a timestamp or signature. A header
+// set on the request itself takes precedence.
+func (c *Client) AddDynamicDefaultHeader(key string, fn func(*Request) string) {
+	c.dynamicHeaders = append(c.dynamicHeaders, dynamicHeader{key: key, fn: fn})
+}
+
+// SetBodyTransformer sets a function that rewrites every request body just
+// before it is sent, e.g. to sign or encrypt it. It is also called for
+// requests without a body.
+func (c *Client) SetBodyTransformer(fn func(method, contentType string, body []byte) ([]byte, error)) {
+	c.bodyTransform = fn
+}
+
+// AddResponseBodyDecoder adds a step to the pipeline that rewrites response
+// bodies in the buffered helpers before they are decoded, e.g. to strip a
+// JSONP callback or unwrap a base64 envelope. Steps run in the order they
+// were added, after decryption and charset conversion.
+func (c *Client) AddResponseBodyDecoder(fn func(contentType string, body []byte) ([]byte, error)) {
+	c.bodyDecoders = append(c.bodyDecoders, fn)
+}
+
+// SetHeaderCasing sets a function that decides the exact casing of outgoing
+// header names, for servers that reject Go's canonical casing. Headers added
+// by net/http itself, such as User-Agent, are not affected.
+func (c *Client) SetHeaderCasing(fn func(string) string) {
+	c.headerCasing = fn
+}
+
+// SetPerAttemptHook sets a function called right before every attempt to
+// send a request, retries included, with the attempt number starting at 1.
+// It can refresh nonces or timestamps, or re-sign the request.
+func (c *Client) SetPerAttemptHook(fn func(attempt int, r *http.Request)) {
+	c.attemptHook = fn
+}
+
+// SetDefaultContentType sets the Content-Type sent with requests that have
+// a body but no Content-Type header of their own.
+func (c *Client) SetDefaultContentType(contentType string) {
+	c.contentType = contentType
+}
+
+// OmitContentTypeWithoutBody stops requests without a body, such as a GET,
+// from sending a Content-Type inherited from the default headers.
+func (c *Client) OmitContentTypeWithoutBody(omit bool) {
+	c.omitContentType = omit
+}
+
 func (c Client) NewRequest(method string, path string) *Request {
 	request := Request{
 		baseURL: c.BaseURL,
+		client:  c,
 		Method:  method, Path: path,
 		Query:   make(map[string]string),
 		Headers: make(map[string]string),
+		Timeout: c.requestTimeout(),
 	}
 
-	for k, v := range c.DefaultHeaders {
+	for k, v := range c.defaultHeaders() {
 		request.SetHeader(k, v)
 	}
 
 	return &request
 }
 
+// Reset clears the body, the query and any headers set on the request, so
+// it can be reused. Method, Path, Timeout and the client's default headers
+// are kept; a default header that was overwritten gets its default value
+// back.
+func (r *Request) Reset() {
+	r.SetBody(nil)
+	r.bodyLength = 0
+	r.bodyReplayable = false
+	r.Query = make(map[string]string)
+	r.queryOrder = nil
+	r.Headers = make(map[string]string)
+	r.extraHeaders = nil
+
+	for k, v := range r.client.defaultHeaders() {
+		r.SetHeader(k, v)
+	}
+}
+
+// clone returns a copy of the request that can be changed without
+// affecting the original.
+func (r *Request) clone() *Request {
+	c := *r
+	c.Query = copyMap(r.Query)
+	c.Headers = copyMap(r.Headers)
+	c.extraHeaders = r.extraHeaders.Clone()
+	c.queryOrder = append([]string(nil), r.queryOrder...)
+
+	if r.skipMiddleware != nil {
+		c.skipMiddleware = make(map[string]bool, len(r.skipMiddleware))
+
+		for k, v := range r.skipMiddleware {
+			c.skipMiddleware[k] = v
+		}
+	}
+
+	return &c
+}
+
+// GetHeaders returns a copy of the request headers.
+func (r *Request) GetHeaders() map[string]string {
+	return copyMap(r.Headers)
+}
+
+// CanonicalHeaders returns the request headers, including the default
+// headers it was created with, as an http.Header with canonical keys. A
+// request header whose key only differs in case from a default header's
+// replaces it; headers repeated with AddHeader get several values. Dynamic
+// default headers are computed when sending and aren't included.
+func (r *Request) CanonicalHeaders() http.Header {
+	defaults := r.client.defaultHeaders()
+	keys := make([]string, 0, len(r.Headers))
+
+	for k := range r.Headers {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	h := make(http.Header, len(keys))
+
+	// Unchanged defaults go first, so the request's own headers override
+	// them whatever the case of their keys.
+	for _, k := range keys {
+		if v, ok := defaults[k]; ok && v == r.Headers[k] {
+			h.Set(k, v)
+		}
+	}
+
+	for _, k := range keys {
+		if v, ok := defaults[k]; !ok || v != r.Headers[k] {
+			h.Set(k, r.Headers[k])
+		}
+	}
+
+	for k, values := range r.extraHeaders {
+		for _, v := range values {
+			h.Add(k, v)
+		}
+	}
+
+	return h
+}
+
+// GetQuery returns a copy of the query parameters.
+func (r *Request) GetQuery() map[string]string {
+	return copyMap(r.Query)
+}
+
+// SetHeader sets the header, replacing any value set before under a key
+// differing only in case, including a default header's.
 func (r *Request) SetHeader(key string, value interface{}) {
+	for k := range r.Headers {
+		if k != key && strings.EqualFold(k, key) {
+			delete(r.Headers, k)
+		}
+	}
+
+	r.extraHeaders.Del(key)
 	r.Headers[key] = fmt.Sprintf("%v", value)
 }
 
+// AddHeader adds a value to the header, keeping those set before, for
+// headers that may be repeated such as Accept or Forwarded.
+func (r *Request) AddHeader(key string, value interface{}) {
+	if r.header(key) == "" {
+		r.SetHeader(key, value)
+		return
+	}
+
+	if r.extraHeaders == nil {
+		r.extraHeaders = make(http.Header)
+	}
+
+	r.extraHeaders.Add(key, fmt.Sprintf("%v", value))
+}
+
+// SetHeaderIfAbsent sets the header only if it isn't set yet, whether by a
+// default header or an earlier call, in any letter case.
+func (r *Request) SetHeaderIfAbsent(key string, value interface{}) {
+	for k := range r.Headers {
+		if strings.EqualFold(k, key) {
+			return
+		}
+	}
+
+	r.SetHeader(key, value)
+}
+
 func (r *Request) SetQuery(key string, value interface{}) {
+	if _, ok := r.Query[key]; !ok {
+		r.queryOrder = append(r.queryOrder, key)
+	}
+
 	r.Query[key] = fmt.Sprintf("%v", value)
 }
 
-func (r *Request) Send() (Response, error) {
-	apiURL, _ := url.Parse(r.baseURL)
+// MergeQuery sets the query parameters of each source in turn, so a key in
+// a later source overrides the same key in an earlier one.
+func (r *Request) MergeQuery(sources ...map[string]string) {
+	for _, source := range sources {
+		for k, v := range source {
+			r.SetQuery(k, v)
+		}
+	}
+}
+
+// Deadline reports when Send would time out if the request were sent now.
+// ok is false when the request has no timeout.
+func (r *Request) Deadline() (deadline time.Time, ok bool) {
+	if r.timeout() <= 0 {
+		return time.Time{}, false
+	}
+
+	return time.Now().Add(r.timeout()), true
+}
+
+// header looks up a request header ignoring the case of the key.
+func (r *Request) header(key string) string {
+	for k, v := range r.Headers {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// SetBodyReaderWithGetBody streams the body from the readers get returns.
+// get is called again for every retry and redirect, so each attempt sends
+// the full body. contentLength is -1 when unknown.
+func (r *Request) SetBodyReaderWithGetBody(get func() (io.ReadCloser, error), contentLength int64) {
+	r.Body = nil
+	r.bodyStream = get
+	r.bodyLength = contentLength
+	r.bodyReplayable = true
+}
+
+func (r *Request) hasBody() bool {
+	return len(r.Body) > 0 || r.bodyStream != nil
+}
+
+func (r *Request) SetBody(body []byte) {
+	r.Body = body
+	r.bodyStream = nil
+}
+
+// BodyBytes returns a copy of the body that will be sent, so it can be
+// inspected or logged without affecting the request. It returns nil for
+// streamed bodies, which can only be read once.
+func (r *Request) BodyBytes() []byte {
+	if r.Body == nil {
+		return nil
+	}
+
+	return append([]byte(nil), r.Body...)
+}
+
+// SetBodyWithType sets the body together with its Content-Type, which is
+// the usual case for non-JSON payloads such as CSV or protobuf.
+func (r *Request) SetBodyWithType(body []byte, contentType string) {
+	r.SetBody(body)
+	r.SetHeader("Content-Type", contentType)
+}
+
+func (r *Request) SetJSONBody(v interface{}) error {
+	body, err := r.encodeJSON(v)
+
+	if err != nil {
+		return err
+	}
+
+	if r.schema != nil {
+		if err := r.schema.validate(body); err != nil {
+			return err
+		}
+	}
+
+	r.SetBodyWithType(body, "application/json")
+
+	return nil
+}
+
+// finalBody returns the body as it goes on the wire, after the client's
+// transformer and cipher have been applied.
+func (r *Request) finalBody() ([]byte, error) {
+	body := r.Body
+
+	if r.client.bodyTransform != nil {
+		transformed, err := r.client.bodyTransform(r.Method, r.header("Content-Type"), body)
+
+		if err != nil {
+			return nil, err
+		}
+
+		body = transformed
+	}
+
+	if r.client.encrypt != nil && len(body) > 0 {
+		encrypted, err := r.client.encrypt(body)
+
+		if err != nil {
+			return nil, err
+		}
+
+		body = encrypted
+	}
+
+	return body, nil
+}
+
+// resolveURL joins the base URL and the path. Without a base URL, an
+// absolute URL in Path is used as it is.
+func (r *Request) resolveURL() (*url.URL, error) {
+	if r.baseURL == "" {
+		if u, err := url.Parse(r.Path); err == nil && u.IsAbs() {
+			return u, nil
+		}
+	}
+
+	apiURL, err := url.Parse(r.baseURL)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL %q: %w", r.baseURL, err)
+	}
+
+	if apiURL.Scheme == "" || apiURL.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q: missing scheme or host", r.baseURL)
+	}
+
 	apiURL.Path = path.Join(apiURL.Path, r.Path)
 
 	if strings.HasSuffix(r.Path, "/") {
 		apiURL.Path = fmt.Sprintf("%s/", apiURL.Path)
 	}
 
-	payloadBuffer := bytes.NewBuffer(r.Body)
+	if r.client.encodePathSegments {
+		apiURL.RawPath = escapePathSegments(apiURL.Path)
+	}
+
+	return apiURL, nil
+}
 
-	client := http.Client{Timeout: time.Duration(10 * time.Second)}
-	req, err := http.NewRequest(r.Method, apiURL.String(), payloadBuffer)
+// BuildURL returns the URL the request will be sent to, including the
+// query, or an error if it can't be built.
+func (r *Request) BuildURL() (*url.URL, error) {
+	apiURL, err := r.resolveURL()
 
 	if err != nil {
-		return Response{}, err
+		return nil, err
 	}
 
-	for k, v := range r.Headers {
-		req.Header.Set(k, v)
+	apiURL.RawQuery, err = r.encodeQuery(apiURL.RawQuery)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := url.Parse(apiURL.String()); err != nil {
+		return nil, err
+	}
+
+	return apiURL, nil
+}
+
+func (r *Request) do(ctx context.Context) (*http.Response, error) {
+	return r.attempt(ctx, 1)
+}
+
+// payload returns the reader for the request body. For streamed bodies it
+// starts producing the stream, so it must only be called once per send.
+func (r *Request) payload() (io.Reader, error) {
+	if r.bodyStream != nil {
+		return r.bodyStream()
+	}
+
+	body, err := r.finalBody()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(body), nil
+}
+
+// newHTTPRequest builds the *http.Request that will be sent, with all
+// headers applied.
+func (r *Request) newHTTPRequest(ctx context.Context, payload io.Reader) (*http.Request, error) {
+	apiURL, err := r.BuildURL()
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.Method, apiURL.String(), payload)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// A CONNECT request targets host:port only; net/http sends it in
+	// authority form when the path is empty.
+	if r.Method == CONNECT {
+		req.URL.Path = ""
+		req.URL.RawPath = ""
+		req.URL.RawQuery = ""
+	}
+
+	if r.sni != "" {
+		req.Host = r.sni
+	}
+
+	for k, v := range r.CanonicalHeaders() {
+		req.Header[k] = v
+	}
+
+	for _, h := range r.client.dynamicHeaders {
+		if req.Header.Get(h.key) == "" {
+			req.Header.Set(h.key, h.fn(r))
+		}
+	}
+
+	if r.client.contentType != "" && r.hasBody() && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", r.client.contentType)
+	}
+
+	if r.client.omitContentType && !r.hasBody() {
+		req.Header.Del("Content-Type")
+	}
+
+	if r.noExpectContinue {
+		req.Header.Del("Expect")
+	}
+
+	if r.client.gzip {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+
+	if r.client.contentDigest && r.bodyStream == nil && req.GetBody != nil && req.ContentLength > 0 {
+		digest, err := contentDigest(req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Content-Digest", digest)
+	}
+
+	if v, ok := r.deadlineHeaderValue(ctx); ok {
+		req.Header.Set(r.deadlineHeader, v)
+	}
+
+	if r.client.headerCasing != nil {
+		cased := make(http.Header, len(req.Header))
+
+		for k, v := range req.Header {
+			cased[r.client.headerCasing(k)] = v
+		}
+
+		req.Header = cased
+	}
+
+	return req, nil
+}
+
+func (r *Request) attempt(ctx context.Context, attempt int) (*http.Response, error) {
+	if err := r.waitRateLimit(ctx); err != nil {
+		return nil, err
+	}
+
+	release, err := r.client.enter()
+
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+
+	// The attempt ends when it fails, or else when the response body is
+	// closed.
+	finish := func(status int, err error) {
+		r.observe(time.Since(start), status, err)
+		release()
+	}
+
+	res, wire, err := r.roundTrip(ctx, attempt)
+
+	if err != nil {
+		finish(0, err)
+		return nil, err
+	}
+
+	res.Body = &countedBody{ReadCloser: res.Body, wire: wire, release: func() {
+		finish(res.StatusCode, nil)
+	}}
+
+	return res, nil
+}
+
+// roundTrip sends the request once. wire counts the body bytes received.
+func (r *Request) roundTrip(ctx context.Context, attempt int) (res *http.Response, wire *countingReader, err error) {
+	if len(r.client.backends) > 0 && !r.pinnedBackend {
+		r.baseURL = r.client.nextBackend()
 	}
 
-	q := req.URL.Query()
+	if err := r.Validate(); err != nil {
+		return nil, nil, err
+	}
 
-	for k, v := range r.Query {
-		q.Add(k, v)
+	// Validate the URL before payload starts a streamed body that would
+	// otherwise never be read.
+	if _, err := r.BuildURL(); err != nil {
+		return nil, nil, err
 	}
 
-	req.URL.RawQuery = q.Encode()
+	payload, err := r.payload()
 
-	res, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := r.newHTTPRequest(ctx, payload)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if r.rotateIdempotencyKey && attempt > 1 && r.hasIdempotencyKey() {
+		req.Header.Set(idempotencyHeader, newIdempotencyKey())
+	}
+
+	if r.bodyStream != nil {
+		req.ContentLength = r.bodyLength
+
+		if r.bodyReplayable {
+			req.GetBody = r.bodyStream
+		}
+	} else if body, ok := payload.(*bytes.Reader); ok {
+		// Byte bodies always go out with an exact Content-Length, never
+		// chunked, and GetBody hands out a fresh reader over the same bytes
+		// so redirects replay the whole body. net/http infers both for a
+		// *bytes.Reader too, but only while the reader is passed unwrapped.
+		req.ContentLength = body.Size()
+		req.GetBody = func() (io.ReadCloser, error) {
+			if body.Size() == 0 {
+				return http.NoBody, nil
+			}
+
+			return io.NopCloser(io.NewSectionReader(body, 0, body.Size())), nil
+		}
+	}
+
+	client := http.Client{
+		Timeout:       r.timeout(),
+		Transport:     r.roundTripper(),
+		CheckRedirect: r.checkRedirect(),
+	}
+
+	if r.client.attemptHook != nil {
+		r.client.attemptHook(attempt, req)
+	}
+
+	if r.debug {
+		r.logRequest(req)
+	}
+
+	res, err = client.Do(req)
+
+	if err != nil {
+		return nil, nil, classifyTimeout(err)
+	}
+
+	if r.client.strictRedirects && isMalformedRedirect(res) {
+		res.Body.Close()
+		return nil, nil, ErrMalformedRedirect
+	}
+
+	wire = &countingReader{ReadCloser: res.Body}
+	res.Body = wire
+
+	if !r.client.noDecompression {
+		body, err := wrapBody(res)
+
+		if err != nil {
+			res.Body.Close()
+			return nil, nil, err
+		}
+
+		res.Body = body
+	}
+
+	if r.debug {
+		r.logResponse(res)
+	}
+
+	return res, wire, nil
+}
+
+func (r *Request) Send() (Response, error) {
+	return r.SendWithCtx(context.Background())
+}
+
+func (r *Request) SendWithCtx(ctx context.Context) (Response, error) {
+	body, res, err := r.sendBuffered(ctx)
 
 	if err != nil {
 		return Response{}, err
 	}
 
+	return Response{Code: res.StatusCode, Body: body, bytesRead: BytesRead(res)}, nil
+}
+
+// SendStatus sends the request and returns only the status code. The body
+// is drained before closing so the connection can be reused.
+func (r *Request) SendStatus() (int, error) {
+	res, err := r.do(context.Background())
+
+	if err != nil {
+		return 0, err
+	}
+
 	defer res.Body.Close()
-	body, _ := io.ReadAll(res.Body)
+	_, err = io.Copy(io.Discard, res.Body)
 
-	return Response{Code: res.StatusCode, Body: body}, nil
+	return res.StatusCode, err
 }
 
-// Unfortunately Go does not support generics with struct methods :-(
-// so we need to pass the request as a function parameter.
-func SendWithJSONResponse[T any](r *Request) (T, error) {
-	res, err := r.Send()
+// SetNoContentStatuses sets the statuses SendExpectNoContent accepts,
+// instead of only 204.
+func (r *Request) SetNoContentStatuses(codes ...int) {
+	r.noContentCodes = codes
+}
 
-	var j T
+// SendExpectNoContent sends a request expected to return no content, as is
+// usual for DELETE and PUT. Any status other than 204, or those set with
+// SetNoContentStatuses, gives a *StatusError. The body is always drained
+// and closed.
+func (r *Request) SendExpectNoContent() (*http.Response, error) {
+	res, err := r.do(context.Background())
 
 	if err != nil {
-		return j, err
+		return nil, err
 	}
 
-	err = json.Unmarshal(res.Body, &j)
+	io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+
+	codes := r.noContentCodes
+
+	if len(codes) == 0 {
+		codes = []int{http.StatusNoContent}
+	}
+
+	for _, code := range codes {
+		if res.StatusCode == code {
+			return res, nil
+		}
+	}
+
+	return res, &StatusError{Code: res.StatusCode}
+}
+
+// stopStream closes res and returns a *StatusError if the client stops
+// streams on its status. A stopped stream is closed without draining,
+// since the point is not to wait for its body.
+func (r *Request) stopStream(res *http.Response) error {
+	for _, code := range r.client.stopStreamOn {
+		if res.StatusCode == code {
+			res.Body.Close()
+			return &StatusError{Code: res.StatusCode}
+		}
+	}
+
+	return nil
+}
+
+// Stream sends the request and hands the response body to fn without
+// buffering it. Whatever fn leaves unread is drained and the body closed
+// once fn returns, so the connection can be reused.
+func (r *Request) Stream(fn func(body io.Reader) error) (*http.Response, error) {
+	res, err := r.do(context.Background())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.stopStream(res); err != nil {
+		return res, err
+	}
+
+	defer drainAndClose(res.Body)
+
+	return res, fn(r.limitDownload(res.Body))
+}
+
+// Unfortunately Go does not support generics with struct methods :-(
+// so we need to pass the request as a function parameter.
+//
+// If *T implements ResponseUnmarshaler, it decodes the response instead of
+// json.Unmarshal.
+func SendWithJSONResponse[T any](r *Request) (T, error) {
+	body, res, err := r.sendBuffered(context.Background())
+
+	var j T
 
 	if err != nil {
 		return j, err
 	}
 
-	return j, nil
+	return decodeJSONResponse[T](r, body, res)
 }
 
 func NewClient(baseUrl string) Client {
-	return Client{BaseURL: baseUrl}
+	return Client{BaseURL: baseUrl, shared: &sharedState{}}
 }
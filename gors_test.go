@@ -0,0 +1,568 @@
+package gors
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSetBodyWithType(t *testing.T) {
+	var contentType, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		contentType, body = r.Header.Get("Content-Type"), string(b)
+	}))
+	defer srv.Close()
+
+	r := NewClient(srv.URL).NewRequest(POST, "/")
+	r.SetBodyWithType([]byte("id,name\n1,gors\n"), "text/csv")
+
+	if _, err := r.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if contentType != "text/csv" {
+		t.Errorf("got Content-Type %q, want text/csv", contentType)
+	}
+
+	if body != "id,name\n1,gors\n" {
+		t.Errorf("got body %q", body)
+	}
+}
+
+// newConnCountingServer starts a server that counts the connections opened
+// to it, to check that connections are reused.
+func newConnCountingServer(h http.Handler) (*httptest.Server, *int32) {
+	var conns int32
+	srv := httptest.NewUnstartedServer(h)
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	srv.Start()
+
+	return srv, &conns
+}
+
+func TestSendStatus(t *testing.T) {
+	srv, conns := newConnCountingServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(strings.Repeat("x", 64<<10)))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	for i := 0; i < 3; i++ {
+		code, err := c.NewRequest(GET, "/").SendStatus()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if code != http.StatusAccepted {
+			t.Fatalf("got status %d, want 202", code)
+		}
+	}
+
+	if n := atomic.LoadInt32(conns); n != 1 {
+		t.Fatalf("server saw %d connections, want 1", n)
+	}
+}
+
+func TestAddDynamicDefaultHeader(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("X-Seq"))
+	}))
+	defer srv.Close()
+
+	seq := 0
+	c := NewClient(srv.URL)
+	c.AddDynamicDefaultHeader("X-Seq", func(*Request) string {
+		seq++
+		return strconv.Itoa(seq)
+	})
+
+	r := c.NewRequest(GET, "/")
+
+	for i := 0; i < 2; i++ {
+		if _, err := r.Send(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(got) != 2 || got[0] != "1" || got[1] != "2" {
+		t.Fatalf("got X-Seq values %q, want [1 2]", got)
+	}
+
+	r.SetHeader("X-Seq", "fixed")
+
+	if _, err := r.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got[2] != "fixed" {
+		t.Fatalf("got X-Seq %q, want the request's own value", got[2])
+	}
+}
+
+func TestSetBodyTransformer(t *testing.T) {
+	var body string
+	var length int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body, length = string(b), r.ContentLength
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetBodyTransformer(func(method, contentType string, body []byte) ([]byte, error) {
+		return append(bytes.ToUpper(body), "!!"...), nil
+	})
+
+	r := c.NewRequest(POST, "/")
+	r.SetBodyWithType([]byte("hello"), "text/plain")
+
+	if _, err := r.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if body != "HELLO!!" {
+		t.Errorf("server got %q, want HELLO!!", body)
+	}
+
+	if length != int64(len("HELLO!!")) {
+		t.Errorf("got Content-Length %d, want %d", length, len("HELLO!!"))
+	}
+}
+
+func TestBodyBytes(t *testing.T) {
+	r := NewClient("http://example.com").NewRequest(POST, "/")
+
+	if err := r.SetJSONBody(map[string]int{"a": 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := r.BodyBytes()
+
+	if string(got) != `{"a":1}` {
+		t.Fatalf("got %q, want %q", got, `{"a":1}`)
+	}
+
+	got[0] = 'X'
+
+	if string(r.Body) != `{"a":1}` {
+		t.Fatalf("changing the copy changed the body to %q", r.Body)
+	}
+
+	r.SetBodyReaderWithGetBody(func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("stream")), nil
+	}, 6)
+
+	if got := r.BodyBytes(); got != nil {
+		t.Fatalf("got %q for a streamed body, want nil", got)
+	}
+}
+
+func TestReset(t *testing.T) {
+	c := NewClient("http://example.com")
+	c.SetDefaultHeaders(map[string]string{"Accept": "application/json"})
+
+	r := c.NewRequest(POST, "/items")
+	r.Timeout = time.Second
+	r.SetHeader("Accept", "text/plain")
+	r.SetHeader("X-Trace", "1")
+	r.SetQuery("page", 2)
+	r.SetBodyReaderWithGetBody(func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("stream")), nil
+	}, 6)
+
+	r.Reset()
+
+	if r.Method != POST || r.Path != "/items" || r.Timeout != time.Second {
+		t.Errorf("lost method, path or timeout: %s %s %v", r.Method, r.Path, r.Timeout)
+	}
+
+	if got := r.Headers["Accept"]; got != "application/json" {
+		t.Errorf("got Accept %q, want the default back", got)
+	}
+
+	if _, ok := r.Headers["X-Trace"]; ok || len(r.Query) != 0 || r.Body != nil {
+		t.Errorf("ad-hoc state left: %v %v %q", r.Headers, r.Query, r.Body)
+	}
+
+	if r.bodyStream != nil || r.bodyLength != 0 || r.bodyReplayable {
+		t.Error("streamed body state left")
+	}
+}
+
+func TestBuildURL(t *testing.T) {
+	r := NewClient("https://api.example.com/v1").NewRequest(GET, "/items/")
+	r.SetQuery("q", "a b")
+
+	u, err := r.BuildURL()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := u.String(), "https://api.example.com/v1/items/?q=a+b"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestBuildURLInvalidBase(t *testing.T) {
+	for _, base := range []string{"http://[::1", "example.com"} {
+		r := NewClient(base).NewRequest(GET, "/items")
+
+		if _, err := r.BuildURL(); err == nil {
+			t.Errorf("%q: BuildURL succeeded", base)
+		}
+
+		if _, err := r.Send(); err == nil {
+			t.Errorf("%q: Send succeeded", base)
+		}
+	}
+}
+
+// captureRawRequest serves a single request on a raw listener and returns
+// its request line and headers exactly as sent, since net/http servers
+// canonicalize header keys.
+func captureRawRequest(t *testing.T, send func(baseURL string)) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer ln.Close()
+
+	got := make(chan string, 1)
+
+	go func() {
+		conn, err := ln.Accept()
+
+		if err != nil {
+			got <- ""
+			return
+		}
+
+		defer conn.Close()
+
+		var head strings.Builder
+		br := bufio.NewReader(conn)
+
+		for {
+			line, err := br.ReadString('\n')
+			head.WriteString(line)
+
+			if err != nil || line == "\r\n" {
+				break
+			}
+		}
+
+		conn.Write([]byte("HTTP/1.1 200 OK\r\nContent-Length: 0\r\n\r\n"))
+		got <- head.String()
+	}()
+
+	send("http://" + ln.Addr().String())
+
+	return <-got
+}
+
+func TestSetHeaderCasing(t *testing.T) {
+	head := captureRawRequest(t, func(baseURL string) {
+		c := NewClient(baseURL)
+		c.SetHeaderCasing(strings.ToLower)
+
+		r := c.NewRequest(GET, "/")
+		r.SetHeader("X-Custom-Thing", "1")
+
+		if _, err := r.Send(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	if !strings.Contains(head, "\r\nx-custom-thing: 1\r\n") {
+		t.Fatalf("header not lowercased:\n%s", head)
+	}
+}
+
+func TestSetDefaultContentType(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Content-Type"))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetDefaultContentType("application/octet-stream")
+
+	r := c.NewRequest(POST, "/")
+	r.SetBody([]byte("raw"))
+
+	if _, err := r.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	r = c.NewRequest(POST, "/")
+	r.SetBodyWithType([]byte("a,b"), "text/csv")
+
+	if _, err := r.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.NewRequest(GET, "/").Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"application/octet-stream", "text/csv", ""}
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("got Content-Types %q, want %q", got, want)
+	}
+}
+
+func TestGettersReturnCopies(t *testing.T) {
+	c := NewClient("http://example.com")
+	c.SetDefaultHeaders(map[string]string{"Accept": "application/json"})
+
+	if c.GetBaseURL() != "http://example.com" {
+		t.Fatalf("got base URL %q", c.GetBaseURL())
+	}
+
+	defaults := c.GetDefaultHeaders()
+	defaults["Accept"] = "text/plain"
+	defaults["X-Extra"] = "1"
+
+	if got := c.GetDefaultHeaders(); len(got) != 1 || got["Accept"] != "application/json" {
+		t.Fatalf("changing the copy changed the client: %v", got)
+	}
+
+	r := c.NewRequest(GET, "/")
+	r.SetQuery("page", "1")
+
+	headers := r.GetHeaders()
+	headers["Accept"] = "text/plain"
+	query := r.GetQuery()
+	query["page"] = "2"
+
+	if r.GetHeaders()["Accept"] != "application/json" {
+		t.Fatalf("changing the copy changed the request headers: %v", r.GetHeaders())
+	}
+
+	if r.GetQuery()["page"] != "1" {
+		t.Fatalf("changing the copy changed the request query: %v", r.GetQuery())
+	}
+}
+
+func TestOmitContentTypeWithoutBody(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Content-Type"))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetDefaultHeaders(map[string]string{"Content-Type": "application/json"})
+	c.OmitContentTypeWithoutBody(true)
+
+	if _, err := c.NewRequest(GET, "/").Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := c.NewRequest(POST, "/")
+	r.SetBody([]byte(`{}`))
+
+	if _, err := r.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(got) != "[ application/json]" {
+		t.Fatalf("got Content-Types %q, want none for the GET only", got)
+	}
+}
+
+func TestSendExpectNoContent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/deleted":
+			w.WriteHeader(http.StatusNoContent)
+		case "/accepted":
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.Write([]byte(`{"id":1}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	res, err := c.NewRequest(DELETE, "/deleted").SendExpectNoContent()
+
+	if err != nil || res.StatusCode != http.StatusNoContent {
+		t.Fatalf("got %v, want a 204 without error", err)
+	}
+
+	_, err = c.NewRequest(PUT, "/ok").SendExpectNoContent()
+
+	var statusErr *StatusError
+
+	if !errors.As(err, &statusErr) || statusErr.Code != http.StatusOK {
+		t.Fatalf("got %v, want a *StatusError for the 200", err)
+	}
+
+	r := c.NewRequest(DELETE, "/accepted")
+	r.SetNoContentStatuses(http.StatusNoContent, http.StatusAccepted)
+
+	if _, err := r.SendExpectNoContent(); err != nil {
+		t.Fatalf("got %v for a status configured as success", err)
+	}
+}
+
+func TestNoBaseURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.String()))
+	}))
+	defer srv.Close()
+
+	r := NewClient("").NewRequest(GET, srv.URL+"/a/b?x=1")
+	r.SetQuery("y", 2)
+
+	res, err := r.Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(res.Body) != "/a/b?x=1&y=2" {
+		t.Fatalf("got %q, want /a/b?x=1&y=2", res.Body)
+	}
+}
+
+func TestSetHeaderIfAbsent(t *testing.T) {
+	c := NewClient("http://example.com")
+	c.SetDefaultHeaders(map[string]string{"Accept": "application/json"})
+
+	r := c.NewRequest(GET, "/")
+	r.SetHeaderIfAbsent("accept", "text/plain")
+	r.SetHeaderIfAbsent("X-Attempt", 5)
+	r.SetHeaderIfAbsent("X-Attempt", 6)
+
+	h := r.CanonicalHeaders()
+
+	if got := h.Values("Accept"); len(got) != 1 || got[0] != "application/json" {
+		t.Errorf("got Accept %q, want the default kept", got)
+	}
+
+	if got := h.Get("X-Attempt"); got != "5" {
+		t.Errorf("got X-Attempt %q, want the first value", got)
+	}
+}
+
+func TestCanonicalHeaders(t *testing.T) {
+	c := NewClient("http://example.com")
+	c.SetDefaultHeaders(map[string]string{"Content-Type": "application/json", "Accept": "a/b"})
+
+	r := c.NewRequest(POST, "/")
+	r.SetHeader("content-type", "text/plain")
+	r.AddHeader("accept", "c/d")
+	r.SetHeader("x-custom-thing", "1")
+	r.Headers["x-direct"] = "2"
+
+	h := r.CanonicalHeaders()
+
+	if got := fmt.Sprint(h["Content-Type"]); got != "[text/plain]" {
+		t.Fatalf("got Content-Type %s, want [text/plain]", got)
+	}
+
+	if got := fmt.Sprint(h["Accept"]); got != "[a/b c/d]" {
+		t.Fatalf("got Accept %s, want [a/b c/d]", got)
+	}
+
+	if h.Get("X-Custom-Thing") != "1" || h.Get("X-Direct") != "2" {
+		t.Fatalf("request headers missing: %v", h)
+	}
+
+	if _, ok := h["x-custom-thing"]; ok {
+		t.Fatalf("non-canonical key kept: %v", h)
+	}
+}
+
+func TestCanonicalHeadersSent(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetDefaultHeaders(map[string]string{"Content-Type": "application/json", "Accept": "a/b"})
+
+	r := c.NewRequest(POST, "/")
+	r.SetHeader("content-type", "text/plain")
+	r.AddHeader("accept", "c/d")
+	r.SetBody([]byte("x"))
+
+	if _, err := r.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(got["Content-Type"]) != "[text/plain]" || fmt.Sprint(got["Accept"]) != "[a/b c/d]" {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestByteBodyContentLength(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s|%v", r.Header.Get("Content-Length"), r.TransferEncoding)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	r := c.NewRequest(PUT, "/")
+	r.SetBody(bytes.Repeat([]byte("a"), 100))
+
+	res, err := r.Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := string(res.Body); got != "100|[]" {
+		t.Fatalf("got %q, want Content-Length 100 and no chunking", got)
+	}
+
+	// The length is that of the body actually sent, after transformers.
+	c.SetBodyTransformer(func(method, contentType string, body []byte) ([]byte, error) {
+		return append(body, "xyz"...), nil
+	})
+
+	r = c.NewRequest(PUT, "/")
+	r.SetBody(bytes.Repeat([]byte("a"), 100))
+
+	res, err = r.Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := string(res.Body); got != "103|[]" {
+		t.Fatalf("got %q, want Content-Length 103 and no chunking", got)
+	}
+}
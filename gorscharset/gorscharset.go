@@ -0,0 +1,29 @@
+// Package gorscharset converts gors response bodies from legacy charsets,
+// such as ISO-8859-1 or Shift_JIS, to UTF-8. It lives in its own package so
+// the core stays free of the golang.org/x/text dependency.
+package gorscharset
+
+import (
+	"io"
+
+	"github.com/kmatsoukas/gors"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// Enable makes the client convert text, XML and JSON response bodies to
+// UTF-8 according to their declared charset.
+func Enable(c *gors.Client) {
+	c.SetCharsetReader(NewReader)
+}
+
+// NewReader returns a reader converting input from the named charset to
+// UTF-8. Names are looked up as in the WHATWG Encoding Standard.
+func NewReader(charset string, input io.Reader) (io.Reader, error) {
+	enc, err := htmlindex.Get(charset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return enc.NewDecoder().Reader(input), nil
+}
@@ -0,0 +1,62 @@
+package gorscharset
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kmatsoukas/gors"
+)
+
+func TestEnable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml; charset=ISO-8859-1")
+		w.Write([]byte("<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><place><name>Caf\xe9 M\xfcller</name></place>"))
+	}))
+	defer srv.Close()
+
+	type place struct {
+		Name string `xml:"name"`
+	}
+
+	c := gors.NewClient(srv.URL)
+	Enable(&c)
+	c.SetDefaultFormat(gors.FormatXML)
+
+	v, err := gors.SendWithResponse[place](c.NewRequest(gors.GET, "/"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.Name != "Café Müller" {
+		t.Fatalf("got %q, want Café Müller", v.Name)
+	}
+}
+
+func TestEnableJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=windows-1252")
+		w.Write([]byte("{\"name\":\"Caf\xe9\"}"))
+	}))
+	defer srv.Close()
+
+	c := gors.NewClient(srv.URL)
+	Enable(&c)
+
+	v, err := gors.SendWithJSONResponse[map[string]string](c.NewRequest(gors.GET, "/"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v["name"] != "Café" {
+		t.Fatalf("got %q, want Café", v["name"])
+	}
+}
+
+func TestNewReaderUnknownCharset(t *testing.T) {
+	if _, err := NewReader("no-such-charset", nil); err == nil {
+		t.Fatal("expected an error")
+	}
+}
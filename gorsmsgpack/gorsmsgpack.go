@@ -0,0 +1,36 @@
+// Package gorsmsgpack adds MessagePack request and response bodies to gors.
+// It lives in its own package so the core stays free of the msgpack
+// dependency.
+package gorsmsgpack
+
+import (
+	"net/http"
+
+	"github.com/kmatsoukas/gors"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const ContentType = "application/msgpack"
+
+// Codec implements gors.Codec for MessagePack.
+type Codec struct{}
+
+func (Codec) ContentType() string {
+	return ContentType
+}
+
+func (Codec) Marshal(v interface{}) ([]byte, error) {
+	return msgpack.Marshal(v)
+}
+
+func (Codec) Unmarshal(data []byte, v interface{}) error {
+	return msgpack.Unmarshal(data, v)
+}
+
+func SetMsgpackBody(r *gors.Request, v any) error {
+	return r.SetCodecBody(Codec{}, v)
+}
+
+func SendWithMsgpackResponse[T any](r *gors.Request) (T, *http.Response, error) {
+	return gors.SendWithCodecResponse[T](r, Codec{})
+}
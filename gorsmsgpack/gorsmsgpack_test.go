@@ -0,0 +1,92 @@
+package gorsmsgpack
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kmatsoukas/gors"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	type item struct {
+		ID   int
+		Name string
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != ContentType {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", ContentType)
+		w.Write(body)
+	}))
+	defer srv.Close()
+
+	r := gors.NewClient(srv.URL).NewRequest(gors.POST, "/echo")
+
+	if err := SetMsgpackBody(r, item{ID: 7, Name: "gors"}); err != nil {
+		t.Fatal(err)
+	}
+
+	v, res, err := SendWithMsgpackResponse[item](r)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d", res.StatusCode)
+	}
+
+	if v != (item{ID: 7, Name: "gors"}) {
+		t.Fatalf("got %+v", v)
+	}
+}
+
+func TestSendWithMsgpackResponseUsesPipeline(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// Promise more than is sent, then drop the connection.
+			w.Header().Set("Content-Length", "100")
+			w.Write([]byte("partial"))
+			w.(http.Flusher).Flush()
+
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+
+			return
+		}
+
+		body, _ := msgpack.Marshal(map[string]string{"name": "gors"})
+		w.Write(body)
+	}))
+	defer srv.Close()
+
+	c := gors.NewClient(srv.URL)
+	c.RetryOnBodyReset(1)
+
+	v, _, err := SendWithMsgpackResponse[map[string]string](c.NewRequest(gors.GET, "/"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v["name"] != "gors" {
+		t.Fatalf("got %v after the retry, want name gors", v)
+	}
+
+	c.SetMaxResponseBytes(4)
+
+	if _, _, err := SendWithMsgpackResponse[map[string]string](c.NewRequest(gors.GET, "/")); !errors.Is(err, gors.ErrResponseTooLarge) {
+		t.Fatalf("got %v, want ErrResponseTooLarge", err)
+	}
+}
@@ -0,0 +1,48 @@
+// Package gorsproto adds protobuf request and response bodies to gors.
+// It lives in its own package so the core stays free of the protobuf
+// dependency.
+package gorsproto
+
+import (
+	"net/http"
+
+	"github.com/kmatsoukas/gors"
+	"google.golang.org/protobuf/proto"
+)
+
+const ContentType = "application/x-protobuf"
+
+func SetProtoBody(r *gors.Request, m proto.Message) error {
+	body, err := proto.Marshal(m)
+
+	if err != nil {
+		return err
+	}
+
+	r.SetBodyWithType(body, ContentType)
+
+	return nil
+}
+
+// messageCodec decodes responses into a given message.
+type messageCodec struct {
+	dst proto.Message
+}
+
+func (messageCodec) ContentType() string {
+	return ContentType
+}
+
+func (messageCodec) Marshal(v interface{}) ([]byte, error) {
+	return proto.Marshal(v.(proto.Message))
+}
+
+func (c messageCodec) Unmarshal(data []byte, _ interface{}) error {
+	return proto.Unmarshal(data, c.dst)
+}
+
+func SendWithProtoResponse[T proto.Message](r *gors.Request, dst T) (*http.Response, error) {
+	_, res, err := gors.SendWithCodecResponse[struct{}](r, messageCodec{dst: dst})
+
+	return res, err
+}
@@ -0,0 +1,89 @@
+package gorsproto
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kmatsoukas/gors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != ContentType {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+
+		b, _ := io.ReadAll(r.Body)
+		in := &wrapperspb.StringValue{}
+
+		if err := proto.Unmarshal(b, in); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		out, _ := proto.Marshal(wrapperspb.String("hello " + in.Value))
+		w.Write(out)
+	}))
+	defer srv.Close()
+
+	r := gors.NewClient(srv.URL).NewRequest(gors.POST, "/")
+
+	if err := SetProtoBody(r, wrapperspb.String("gors")); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := &wrapperspb.StringValue{}
+	res, err := SendWithProtoResponse(r, dst)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d", res.StatusCode)
+	}
+
+	if dst.Value != "hello gors" {
+		t.Fatalf("got %q, want %q", dst.Value, "hello gors")
+	}
+}
+
+func TestSendWithProtoResponseRetries(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// Promise more than is sent, then drop the connection.
+			w.Header().Set("Content-Length", "100")
+			w.Write([]byte("partial"))
+			w.(http.Flusher).Flush()
+
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+
+			return
+		}
+
+		out, _ := proto.Marshal(wrapperspb.String("hello"))
+		w.Write(out)
+	}))
+	defer srv.Close()
+
+	c := gors.NewClient(srv.URL)
+	c.RetryOnBodyReset(1)
+
+	dst := &wrapperspb.StringValue{}
+
+	if _, err := SendWithProtoResponse(c.NewRequest(gors.GET, "/"), dst); err != nil {
+		t.Fatal(err)
+	}
+
+	if dst.Value != "hello" {
+		t.Fatalf("got %q after the retry, want hello", dst.Value)
+	}
+}
@@ -0,0 +1,66 @@
+// Package gorstest provides helpers for testing code that sends requests
+// with gors, such as test servers checking what they received.
+package gorstest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DecodeBody reads the body of a request received by a test server and
+// undoes its Content-Encoding, so tests can assert on the plain body.
+// gzip and deflate are supported, also when several encodings are stacked.
+func DecodeBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	encodings := strings.Split(req.Header.Get("Content-Encoding"), ",")
+
+	// Encodings are listed in the order they were applied.
+	for i := len(encodings) - 1; i >= 0; i-- {
+		body, err = decode(strings.ToLower(strings.TrimSpace(encodings[i])), body)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return body, nil
+}
+
+func decode(encoding string, body []byte) ([]byte, error) {
+	var r io.ReadCloser
+	var err error
+
+	switch encoding {
+	case "", "identity":
+		return body, nil
+	case "gzip", "x-gzip":
+		r, err = gzip.NewReader(bytes.NewReader(body))
+	case "deflate":
+		r, err = zlib.NewReader(bytes.NewReader(body))
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
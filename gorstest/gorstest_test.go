@@ -0,0 +1,75 @@
+package gorstest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kmatsoukas/gors"
+)
+
+func gzipped(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+
+	if _, err := zw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestDecodeBody(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := DecodeBody(r)
+
+		if err != nil {
+			t.Error(err)
+		}
+
+		got = string(body)
+	}))
+	defer srv.Close()
+
+	r := gors.NewClient(srv.URL).NewRequest(gors.POST, "/")
+	r.SetBody(gzipped(t, []byte(`{"name":"gors"}`)))
+	r.SetHeader("Content-Encoding", "gzip")
+
+	if _, err := r.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got != `{"name":"gors"}` {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestDecodeBodyStacked(t *testing.T) {
+	var deflated bytes.Buffer
+	zw := zlib.NewWriter(&deflated)
+	zw.Write([]byte("hello"))
+	zw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(gzipped(t, deflated.Bytes())))
+	req.Header.Set("Content-Encoding", "deflate, gzip")
+
+	body, err := DecodeBody(req)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(body) != "hello" {
+		t.Fatalf("got %q, want hello", body)
+	}
+}
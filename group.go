@@ -0,0 +1,73 @@
+package gors
+
+import (
+	"context"
+	"sync"
+)
+
+// RequestGroup sends a set of requests concurrently under one shared
+// context, so they can all be canceled together.
+type RequestGroup struct {
+	mu       sync.Mutex
+	requests []*Request
+	cancel   context.CancelFunc
+	canceled bool
+}
+
+func (c Client) NewGroup() *RequestGroup {
+	return &RequestGroup{}
+}
+
+func (g *RequestGroup) Add(r *Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.requests = append(g.requests, r)
+}
+
+// SendAll sends every request in the group concurrently and waits for all
+// of them. Responses and errors are in the order the requests were added.
+func (g *RequestGroup) SendAll(ctx context.Context) ([]Response, []error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	g.mu.Lock()
+	requests := append([]*Request(nil), g.requests...)
+	g.cancel = cancel
+
+	if g.canceled {
+		cancel()
+	}
+
+	g.mu.Unlock()
+
+	responses := make([]Response, len(requests))
+	errs := make([]error, len(requests))
+	var wg sync.WaitGroup
+
+	for i, r := range requests {
+		wg.Add(1)
+
+		go func(i int, r *Request) {
+			defer wg.Done()
+			responses[i], errs[i] = r.SendWithCtx(ctx)
+		}(i, r)
+	}
+
+	wg.Wait()
+
+	return responses, errs
+}
+
+// Cancel aborts every request of the group that is in flight. Once
+// canceled, SendAll fails all requests straight away.
+func (g *RequestGroup) Cancel() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.canceled = true
+
+	if g.cancel != nil {
+		g.cancel()
+	}
+}
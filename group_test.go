@@ -0,0 +1,46 @@
+package gors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestGroupCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	g := c.NewGroup()
+
+	for i := 0; i < 3; i++ {
+		g.Add(c.NewRequest(GET, "/slow"))
+	}
+
+	time.AfterFunc(50*time.Millisecond, g.Cancel)
+
+	start := time.Now()
+	_, errs := g.SendAll(context.Background())
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("SendAll took %v after canceling", elapsed)
+	}
+
+	if len(errs) != 3 {
+		t.Fatalf("got %d errors, want 3", len(errs))
+	}
+
+	for i, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("request %d: got %v, want context.Canceled", i, err)
+		}
+	}
+}
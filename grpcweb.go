@@ -0,0 +1,81 @@
+package gors
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"net/textproto"
+)
+
+const (
+	grpcWebDataFrame    = 0x00
+	grpcWebTrailerFrame = 0x80
+)
+
+// GRPCWebFrame wraps a serialized message in a gRPC-Web data frame: a flag
+// byte followed by the big-endian message length.
+func GRPCWebFrame(message []byte) []byte {
+	frame := make([]byte, 5+len(message))
+	frame[0] = grpcWebDataFrame
+	binary.BigEndian.PutUint32(frame[1:5], uint32(len(message)))
+	copy(frame[5:], message)
+
+	return frame
+}
+
+// SetGRPCWebBody frames message as a unary gRPC-Web request body.
+func (r *Request) SetGRPCWebBody(message []byte) {
+	r.SetBodyWithType(GRPCWebFrame(message), "application/grpc-web+proto")
+	r.SetHeader("X-Grpc-Web", "1")
+}
+
+// GRPCWebResponse is a decoded gRPC-Web response body.
+type GRPCWebResponse struct {
+	Messages [][]byte
+	Trailer  http.Header
+}
+
+// ParseGRPCWebResponse splits a gRPC-Web response body into its data
+// frames and trailer.
+func ParseGRPCWebResponse(body []byte) (*GRPCWebResponse, error) {
+	res := GRPCWebResponse{Trailer: make(http.Header)}
+
+	for len(body) > 0 {
+		if len(body) < 5 {
+			return nil, errors.New("truncated gRPC-Web frame header")
+		}
+
+		flags := body[0]
+		size := binary.BigEndian.Uint32(body[1:5])
+		body = body[5:]
+
+		if uint32(len(body)) < size {
+			return nil, errors.New("truncated gRPC-Web frame")
+		}
+
+		payload := body[:size]
+		body = body[size:]
+
+		if flags&grpcWebTrailerFrame == 0 {
+			res.Messages = append(res.Messages, payload)
+			continue
+		}
+
+		// Trailers are sent as HTTP/1 header lines; the extra CRLF ends the
+		// header block for the reader.
+		tr := textproto.NewReader(bufio.NewReader(bytes.NewReader(append(payload, "\r\n"...))))
+		trailer, err := tr.ReadMIMEHeader()
+
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range trailer {
+			res.Trailer[k] = append(res.Trailer[k], v...)
+		}
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,58 @@
+package gors
+
+import (
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGRPCWeb(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if ct := r.Header.Get("Content-Type"); ct != "application/grpc-web+proto" {
+			t.Errorf("got Content-Type %q", ct)
+		}
+
+		res, err := ParseGRPCWebResponse(body)
+
+		if err != nil || len(res.Messages) != 1 {
+			t.Errorf("got %v, %v", res, err)
+			return
+		}
+
+		trailer := []byte("grpc-status: 0\r\ngrpc-message: ok\r\n")
+		frame := make([]byte, 5, 5+len(trailer))
+		frame[0] = 0x80
+		binary.BigEndian.PutUint32(frame[1:5], uint32(len(trailer)))
+
+		w.Write(GRPCWebFrame(append([]byte("echo:"), res.Messages[0]...)))
+		w.Write(append(frame, trailer...))
+	}))
+	defer srv.Close()
+
+	r := NewClient(srv.URL).NewRequest(POST, "/pkg.Service/Echo")
+	r.SetGRPCWebBody([]byte("hello"))
+
+	res, err := r.Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := ParseGRPCWebResponse(res.Body)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(parsed.Messages) != 1 || string(parsed.Messages[0]) != "echo:hello" {
+		t.Fatalf("got messages %q", parsed.Messages)
+	}
+
+	if parsed.Trailer.Get("Grpc-Status") != "0" || parsed.Trailer.Get("Grpc-Message") != "ok" {
+		t.Fatalf("got trailer %v", parsed.Trailer)
+	}
+}
@@ -0,0 +1,79 @@
+package gors
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// HeadInfo holds the resource metadata returned by a HEAD request.
+// ContentLength is -1 when the server did not send it.
+type HeadInfo struct {
+	ContentLength int64
+	ContentType   string
+	LastModified  time.Time
+	ETag          string
+}
+
+func (c Client) Head(path string) (*HeadInfo, *http.Response, error) {
+	res, err := c.NewRequest(HEAD, path).do(context.Background())
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res.Body.Close()
+
+	info := HeadInfo{
+		ContentLength: ContentLength(res),
+		ContentType:   ContentType(res),
+		ETag:          res.Header.Get("ETag"),
+	}
+
+	info.LastModified, _ = LastModified(res)
+
+	return &info, res, nil
+}
+
+// Exists reports whether the resource at path exists, using a HEAD request.
+// A 2xx status means it exists and 404 means it doesn't; any other status is
+// returned as a *StatusError.
+func (c Client) Exists(path string) (bool, error) {
+	_, res, err := c.Head(path)
+
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+		return true, nil
+	case res.StatusCode == http.StatusNotFound:
+		return false, nil
+	default:
+		return false, &StatusError{Code: res.StatusCode}
+	}
+}
+
+// SetHealthPath sets the path Ping checks instead of the base URL.
+func (c *Client) SetHealthPath(path string) {
+	c.healthPath = path
+}
+
+// Ping sends a HEAD request to the health path, or the base URL, and
+// returns nil for a 2xx or 3xx status, for readiness checks.
+func (c Client) Ping(ctx context.Context) error {
+	res, err := c.NewRequest(HEAD, c.healthPath).do(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	res.Body.Close()
+
+	if res.StatusCode >= 200 && res.StatusCode < 400 {
+		return nil
+	}
+
+	return &StatusError{Code: res.StatusCode}
+}
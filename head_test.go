@@ -0,0 +1,105 @@
+package gors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHead(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != HEAD {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Length", "42")
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("ETag", `"abc"`)
+		w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+	}))
+	defer srv.Close()
+
+	info, res, err := NewClient(srv.URL).Head("/file")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d", res.StatusCode)
+	}
+
+	want := HeadInfo{
+		ContentLength: 42,
+		ContentType:   "text/plain",
+		LastModified:  time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC),
+		ETag:          `"abc"`,
+	}
+
+	if info.ContentLength != want.ContentLength || info.ContentType != want.ContentType || info.ETag != want.ETag || !info.LastModified.Equal(want.LastModified) {
+		t.Fatalf("got %+v, want %+v", *info, want)
+	}
+}
+
+func TestExists(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/found":
+			w.Header().Set("Content-Length", "0")
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	if ok, err := c.Exists("/found"); err != nil || !ok {
+		t.Fatalf("found: got %v, %v", ok, err)
+	}
+
+	if ok, err := c.Exists("/missing"); err != nil || ok {
+		t.Fatalf("missing: got %v, %v", ok, err)
+	}
+
+	if _, err := c.Exists("/broken"); err == nil {
+		t.Fatal("expected an error for a 500 response")
+	}
+}
+
+func TestPing(t *testing.T) {
+	healthy := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != HEAD || r.URL.Path != "/healthz" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetHealthPath("/healthz")
+
+	if err := c.Ping(context.Background()); err != nil {
+		t.Fatalf("healthy backend: %v", err)
+	}
+
+	healthy = false
+	err := c.Ping(context.Background())
+
+	var statusErr *StatusError
+
+	if !errors.As(err, &statusErr) || statusErr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("got %v, want a 503 *StatusError", err)
+	}
+}
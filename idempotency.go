@@ -0,0 +1,42 @@
+package gors
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+const idempotencyHeader = "Idempotency-Key"
+
+// SetIdempotencyKey sends key as Idempotency-Key header, or a random key
+// when key is empty. Retries reuse the key, so the server can tell them
+// apart from new operations; this also makes transport errors retryable
+// for non-idempotent methods such as POST.
+func (r *Request) SetIdempotencyKey(key string) {
+	if key == "" {
+		key = newIdempotencyKey()
+	}
+
+	r.SetHeader(idempotencyHeader, key)
+}
+
+// RotateIdempotencyKeyOnRetry sends every retry with a fresh random
+// Idempotency-Key instead of the original one, for endpoints that reject a
+// key they have seen before.
+func (r *Request) RotateIdempotencyKeyOnRetry(rotate bool) {
+	r.rotateIdempotencyKey = rotate
+}
+
+func (r *Request) hasIdempotencyKey() bool {
+	return r.header(idempotencyHeader) != ""
+}
+
+// newIdempotencyKey returns a random UUID.
+func newIdempotencyKey() string {
+	var b [16]byte
+	rand.Read(b[:])
+
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,70 @@
+package gors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func idempotencyKeys(t *testing.T, prepare func(r *Request)) []string {
+	t.Helper()
+
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+
+		if len(keys) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetRetryPolicy(RetryPolicy{MaxRetries: 2})
+
+	r := c.NewRequest(POST, "/payments")
+	prepare(r)
+
+	res, err := r.Send()
+
+	if err != nil || res.Code != http.StatusOK {
+		t.Fatalf("got %d, %v", res.Code, err)
+	}
+
+	return keys
+}
+
+func TestIdempotencyKeyReusedOnRetry(t *testing.T) {
+	keys := idempotencyKeys(t, func(r *Request) {
+		r.SetIdempotencyKey("")
+	})
+
+	if len(keys) != 3 || len(keys[0]) != 36 {
+		t.Fatalf("got keys %q, want 3 random UUIDs", keys)
+	}
+
+	if keys[1] != keys[0] || keys[2] != keys[0] {
+		t.Fatalf("got keys %q, want the same key for every attempt", keys)
+	}
+}
+
+func TestRotateIdempotencyKeyOnRetry(t *testing.T) {
+	keys := idempotencyKeys(t, func(r *Request) {
+		r.SetIdempotencyKey("first")
+		r.RotateIdempotencyKeyOnRetry(true)
+	})
+
+	if len(keys) != 3 || keys[0] != "first" {
+		t.Fatalf("got keys %q, want the set key on the first attempt", keys)
+	}
+
+	seen := map[string]bool{}
+
+	for _, k := range keys {
+		if k == "" || seen[k] {
+			t.Fatalf("got keys %q, want a fresh key for every attempt", keys)
+		}
+
+		seen[k] = true
+	}
+}
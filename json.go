@@ -0,0 +1,268 @@
+package gors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// sendBuffered sends the request and reads the whole body, closing it. The
+// response is returned whenever one arrived, even if reading its body
+// failed.
+func (r *Request) sendBuffered(ctx context.Context) ([]byte, *http.Response, error) {
+	if b := budgetFrom(ctx); b != nil {
+		left := b.left()
+
+		if left <= 0 {
+			return nil, nil, ErrBudgetExhausted
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, left)
+		defer cancel()
+
+		start := time.Now()
+		defer func() { b.spend(time.Since(start)) }()
+	}
+
+	memoKey, memoize := r.memoKey()
+
+	if memoize {
+		if body, res, ok := r.memoLookup(memoKey); ok {
+			return body, res, nil
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		var body []byte
+		res, err := r.attempt(ctx, attempt)
+
+		if err == nil {
+			body, err = r.readBody(res.Body)
+			res.Body.Close()
+			err = classifyTimeout(err)
+
+			if err != nil && !r.noRetry && attempt <= r.client.bodyResetRetries && isIdempotent(r.Method) && isConnectionReset(err) {
+				continue
+			}
+		}
+
+		if r.shouldRetry(attempt, res, err) {
+			if err := r.waitRetry(ctx, attempt, res); err != nil {
+				return nil, res, err
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return nil, res, err
+		}
+
+		if r.client.decrypt != nil && len(body) > 0 {
+			body, err = r.client.decrypt(body)
+		}
+
+		if err == nil && r.client.charsetReader != nil {
+			body, err = r.toUTF8(res.Header.Get("Content-Type"), body)
+		}
+
+		for _, decode := range r.client.bodyDecoders {
+			if err != nil {
+				break
+			}
+
+			body, err = decode(res.Header.Get("Content-Type"), body)
+		}
+
+		if err == nil {
+			err = r.responseError(res.StatusCode, body)
+		}
+
+		if err == nil && r.responseSchema != nil && r.client.responseSchemas && isSuccess(res.StatusCode) {
+			err = r.responseSchema.validate(body)
+		}
+
+		if err == nil && memoize && isSuccess(res.StatusCode) {
+			r.memoStore(memoKey, body, res)
+		}
+
+		return body, res, err
+	}
+}
+
+// decodeJSONResponse decodes a response read by sendBuffered for
+// SendWithJSONResponse and SendWithJSONResponseSafe.
+func decodeJSONResponse[T any](r *Request, body []byte, res *http.Response) (T, error) {
+	var j T
+
+	if u, ok := any(&j).(ResponseUnmarshaler); ok {
+		replay := *res
+		replay.Body = io.NopCloser(bytes.NewReader(body))
+
+		if err := u.UnmarshalResponse(&replay); err != nil {
+			var zero T
+			return zero, err
+		}
+
+		return j, nil
+	}
+
+	err := r.decodeJSON(body, &j)
+
+	if err != nil {
+		if r.client.partialDecode {
+			r.decodePartial(body, &j)
+			return j, err
+		}
+
+		var zero T
+		return zero, err
+	}
+
+	return j, nil
+}
+
+// SendWithJSONResponseSafe is SendWithJSONResponse that also returns the
+// response, for its status and headers. The body is closed on every path,
+// including when sending or decoding fails, so there is nothing to clean
+// up; res is nil only if no response arrived.
+func SendWithJSONResponseSafe[T any](r *Request) (T, *http.Response, error) {
+	body, res, err := r.sendBuffered(context.Background())
+
+	if err != nil {
+		var zero T
+		return zero, res, err
+	}
+
+	v, err := decodeJSONResponse[T](r, body, res)
+
+	return v, res, err
+}
+
+// SendWithFlexibleJSON decodes responses that are either a single object or
+// an array of objects into a slice, for endpoints whose shape depends on the
+// number of results.
+func SendWithFlexibleJSON[T any](r *Request) ([]T, *http.Response, error) {
+	body, res, err := r.sendBuffered(context.Background())
+
+	if err != nil {
+		return nil, res, err
+	}
+
+	trimmed := bytes.TrimLeft(body, " \t\r\n")
+
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var items []T
+		err = r.decodeJSON(body, &items)
+
+		return items, res, err
+	}
+
+	var item T
+
+	if err := r.decodeJSON(body, &item); err != nil {
+		return nil, res, err
+	}
+
+	return []T{item}, res, nil
+}
+
+// SendWithJSONAndETag decodes the JSON response and also returns its ETag,
+// for conditional requests and optimistic concurrency.
+func SendWithJSONAndETag[T any](r *Request) (T, string, *http.Response, error) {
+	var v T
+
+	body, res, err := r.sendBuffered(context.Background())
+
+	if err != nil {
+		return v, "", res, err
+	}
+
+	etag := res.Header.Get("ETag")
+	err = r.decodeJSON(body, &v)
+
+	return v, etag, res, err
+}
+
+// DecodeInto decodes the JSON body of res into dst and closes the body.
+// Reusing dst across calls avoids allocating a new value each time.
+func DecodeInto[T any](res *http.Response, dst *T) error {
+	defer drainAndClose(res.Body)
+
+	if err := json.NewDecoder(res.Body).Decode(dst); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// SendJSON sends body as the JSON request body and decodes the JSON
+// response, combining SetJSONBody and SendWithJSONResponse.
+func SendJSON[Req, Res any](r *Request, body Req) (Res, *http.Response, error) {
+	var v Res
+
+	if err := r.SetJSONBody(body); err != nil {
+		return v, nil, err
+	}
+
+	data, res, err := r.sendBuffered(context.Background())
+
+	if err != nil {
+		return v, res, err
+	}
+
+	err = r.decodeJSON(data, &v)
+
+	return v, res, err
+}
+
+// MustJSON decodes the JSON body of res into a T and closes the body,
+// panicking on any error. It is meant for terse tests and scripts; use
+// DecodeInto where errors must be handled.
+func MustJSON[T any](res *http.Response) T {
+	var v T
+
+	if err := DecodeInto(res, &v); err != nil {
+		panic(fmt.Sprintf("gors: MustJSON: %v", err))
+	}
+
+	return v
+}
+
+// SendWithJSONResponseDeadline is SendWithJSONResponse with a hard deadline
+// covering the round trip, reading the body and decoding it. A body still
+// arriving when the deadline passes is abandoned, and a decode finishing
+// after it is discarded; both give context.DeadlineExceeded.
+func SendWithJSONResponseDeadline[T any](r *Request, deadline time.Time) (T, *http.Response, error) {
+	var v T
+
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	body, res, err := r.sendBuffered(ctx)
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return v, res, ctx.Err()
+		}
+
+		return v, res, err
+	}
+
+	if err := r.decodeJSON(body, &v); err != nil {
+		var zero T
+		return zero, res, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		var zero T
+		return zero, res, err
+	}
+
+	return v, res, nil
+}
@@ -0,0 +1,312 @@
+package gors
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSendWithFlexibleJSON(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/one":
+			w.Write([]byte(` {"id":1}`))
+		case "/many":
+			w.Write([]byte("\n[{\"id\":1},{\"id\":2}]"))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	tests := []struct {
+		path string
+		want []item
+	}{
+		{"/one", []item{{1}}},
+		{"/many", []item{{1}, {2}}},
+	}
+
+	for _, tt := range tests {
+		got, _, err := SendWithFlexibleJSON[item](c.NewRequest(GET, tt.path))
+
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+
+		if len(got) != len(tt.want) {
+			t.Fatalf("%s: got %v, want %v", tt.path, got, tt.want)
+		}
+
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: got %v, want %v", tt.path, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestSendWithJSONAndETag(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"name":"gors"}`))
+	}))
+	defer srv.Close()
+
+	v, etag, _, err := SendWithJSONAndETag[struct{ Name string }](NewClient(srv.URL).NewRequest(GET, "/"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.Name != "gors" || etag != `"v1"` {
+		t.Fatalf("got %q, %q", v.Name, etag)
+	}
+}
+
+func TestDecodeInto(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"` + r.URL.Path[1:] + `"}`))
+	}))
+	defer srv.Close()
+
+	var v struct{ ID string }
+
+	for _, id := range []string{"a", "b", "c"} {
+		res, err := http.Get(srv.URL + "/" + id)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := DecodeInto(res, &v); err != nil {
+			t.Fatal(err)
+		}
+
+		if v.ID != id {
+			t.Fatalf("got %q, want %q", v.ID, id)
+		}
+	}
+}
+
+func TestSendJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in struct{ Name string }
+
+		if r.Header.Get("Content-Type") != "application/json" || json.NewDecoder(r.Body).Decode(&in) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": 7, "name": in.Name})
+	}))
+	defer srv.Close()
+
+	type createUser struct{ Name string }
+
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	v, res, err := SendJSON[createUser, user](NewClient(srv.URL).NewRequest(POST, "/users"), createUser{Name: "gors"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.StatusCode != http.StatusCreated || v != (user{ID: 7, Name: "gors"}) {
+		t.Fatalf("got %d, %+v", res.StatusCode, v)
+	}
+}
+
+func TestSendWithJSONResponseReusesConnection(t *testing.T) {
+	srv, conns := newConnCountingServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"a":1}` + strings.Repeat(" ", 8<<10) + "\n"))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	for i := 0; i < 3; i++ {
+		v, err := SendWithJSONResponse[map[string]int](c.NewRequest(GET, "/"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if v["a"] != 1 {
+			t.Fatalf("got %v", v)
+		}
+	}
+
+	if n := atomic.LoadInt32(conns); n != 1 {
+		t.Fatalf("server saw %d connections, want 1", n)
+	}
+}
+
+func TestSendJSONReader(t *testing.T) {
+	const count = 10000
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte("["))
+
+		for i := 0; i < count; i++ {
+			if i > 0 {
+				zw.Write([]byte(","))
+			}
+
+			fmt.Fprintf(zw, `{"i":%d}`, i)
+		}
+
+		zw.Write([]byte("]"))
+		zw.Close()
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.EnableGzipRequests()
+
+	d, res, err := SendJSONReader(c.NewRequest(GET, "/"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer res.Body.Close()
+
+	if tok, err := d.Token(); err != nil || tok != json.Delim('[') {
+		t.Fatalf("got %v, %v, want the opening bracket", tok, err)
+	}
+
+	n := 0
+
+	for d.More() {
+		var v struct{ I int }
+
+		if err := d.Decode(&v); err != nil {
+			t.Fatal(err)
+		}
+
+		if v.I != n {
+			t.Fatalf("got element %d at position %d", v.I, n)
+		}
+
+		n++
+	}
+
+	if n != count {
+		t.Fatalf("decoded %d elements, want %d", n, count)
+	}
+}
+
+func TestSendJSONReaderStopStreamOn(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error":"forbidden"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.StopStreamOn(http.StatusForbidden)
+
+	d, res, err := SendJSONReader(c.NewRequest(GET, "/"))
+
+	var statusErr *StatusError
+
+	if !errors.As(err, &statusErr) || statusErr.Code != http.StatusForbidden {
+		t.Fatalf("got %v, want a 403 *StatusError", err)
+	}
+
+	if d != nil || res == nil {
+		t.Fatalf("got decoder %v and response %v, want only the response", d, res)
+	}
+}
+
+func TestMustJSON(t *testing.T) {
+	response := func(body string) *http.Response {
+		return &http.Response{Body: io.NopCloser(strings.NewReader(body))}
+	}
+
+	if v := MustJSON[map[string]int](response(`{"a":3}`)); v["a"] != 3 {
+		t.Fatalf("got %v, want a=3", v)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("malformed JSON didn't panic")
+		}
+	}()
+
+	MustJSON[map[string]int](response(`{"a":`))
+}
+
+func TestSendWithJSONResponseSafeNoLeak(t *testing.T) {
+	srv, conns := newConnCountingServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/err" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+
+		w.Write([]byte("{not json"))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetRetryPolicy(RetryPolicy{})
+
+	for i := 0; i < 50; i++ {
+		path := "/"
+
+		if i%2 == 1 {
+			path = "/err"
+		}
+
+		_, res, err := SendWithJSONResponseSafe[map[string]any](c.NewRequest(GET, path))
+
+		if err == nil {
+			t.Fatalf("GET %s: expected an error", path)
+		}
+
+		if res == nil {
+			t.Fatalf("GET %s: got no response", path)
+		}
+	}
+
+	if n := atomic.LoadInt32(conns); n != 1 {
+		t.Fatalf("opened %d connections, want 1", n)
+	}
+}
+
+func TestSendWithJSONResponseSafeReadError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"a long enough body"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetMaxResponseBytes(4)
+
+	_, res, err := SendWithJSONResponseSafe[map[string]string](c.NewRequest(GET, "/"))
+
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("got %v, want ErrResponseTooLarge", err)
+	}
+
+	if res == nil || res.StatusCode != http.StatusOK {
+		t.Fatalf("got response %v, want the 200 that arrived", res)
+	}
+}
@@ -0,0 +1,30 @@
+package gors
+
+import "encoding/json"
+
+type jsonCodec struct {
+	marshal   func(v interface{}) ([]byte, error)
+	unmarshal func(data []byte, v interface{}) error
+}
+
+// SetJSONCodec replaces encoding/json for request and response bodies, e.g.
+// with a faster or more lenient implementation.
+func (c *Client) SetJSONCodec(marshal func(v interface{}) ([]byte, error), unmarshal func(data []byte, v interface{}) error) {
+	c.json = &jsonCodec{marshal: marshal, unmarshal: unmarshal}
+}
+
+// SetJSONCodec overrides the client's JSON codec for this request only.
+func (r *Request) SetJSONCodec(marshal func(v interface{}) ([]byte, error), unmarshal func(data []byte, v interface{}) error) {
+	r.json = &jsonCodec{marshal: marshal, unmarshal: unmarshal}
+}
+
+func (r *Request) jsonCodec() *jsonCodec {
+	switch {
+	case r.json != nil:
+		return r.json
+	case r.client.json != nil:
+		return r.client.json
+	default:
+		return &jsonCodec{marshal: json.Marshal, unmarshal: json.Unmarshal}
+	}
+}
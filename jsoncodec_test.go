@@ -0,0 +1,45 @@
+package gors
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestJSONCodec(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"gors"}`))
+	}))
+	defer srv.Close()
+
+	var clientCalls, requestCalls int
+
+	c := NewClient(srv.URL)
+	c.SetJSONCodec(json.Marshal, func(data []byte, v interface{}) error {
+		clientCalls++
+		return json.Unmarshal(data, v)
+	})
+
+	custom := c.NewRequest(GET, "/")
+	custom.SetJSONCodec(json.Marshal, func(data []byte, v interface{}) error {
+		requestCalls++
+		return json.Unmarshal(data, v)
+	})
+
+	for _, r := range []*Request{custom, c.NewRequest(GET, "/")} {
+		v, err := SendWithJSONResponse[struct{ Name string }](r)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if v.Name != "gors" {
+			t.Fatalf("got name %q", v.Name)
+		}
+	}
+
+	if requestCalls != 1 || clientCalls != 1 {
+		t.Fatalf("got %d request codec calls and %d client codec calls, want 1 each", requestCalls, clientCalls)
+	}
+}
@@ -0,0 +1,52 @@
+package gors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const snippetRadius = 20
+
+// JSONError wraps a JSON decoding error with the position it occurred at
+// and the surrounding part of the body.
+type JSONError struct {
+	Err     error
+	Offset  int64
+	Snippet string
+}
+
+func (e *JSONError) Error() string {
+	return fmt.Sprintf("%v (at offset %d near %q)", e.Err, e.Offset, e.Snippet)
+}
+
+func (e *JSONError) Unwrap() error {
+	return e.Err
+}
+
+func newJSONError(data []byte, err error) error {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return err
+	}
+
+	start, end := offset-snippetRadius, offset+snippetRadius
+
+	if start < 0 {
+		start = 0
+	}
+
+	if end > int64(len(data)) {
+		end = int64(len(data))
+	}
+
+	return &JSONError{Err: err, Offset: offset, Snippet: string(data[start:end])}
+}
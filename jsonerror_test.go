@@ -0,0 +1,43 @@
+package gors
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestJSONErrorContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"a": 1, "b": [1, 2,, 3], "c": "some longer text"}`))
+	}))
+	defer srv.Close()
+
+	_, err := SendWithJSONResponse[map[string]interface{}](NewClient(srv.URL).NewRequest(GET, "/"))
+
+	var jsonErr *JSONError
+
+	if !errors.As(err, &jsonErr) {
+		t.Fatalf("got %v, want a *JSONError", err)
+	}
+
+	if jsonErr.Offset != 21 {
+		t.Errorf("got offset %d, want 21", jsonErr.Offset)
+	}
+
+	if !strings.Contains(jsonErr.Snippet, "2,, 3") {
+		t.Errorf("snippet %q doesn't show the error", jsonErr.Snippet)
+	}
+
+	if !strings.Contains(err.Error(), "offset 21") {
+		t.Errorf("message %q lacks the offset", err)
+	}
+
+	var syntaxErr *json.SyntaxError
+
+	if !errors.As(errors.Unwrap(jsonErr), &syntaxErr) {
+		t.Errorf("unwrapped %v, want the *json.SyntaxError", errors.Unwrap(jsonErr))
+	}
+}
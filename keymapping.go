@@ -0,0 +1,283 @@
+package gors
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// KeyStyle selects how JSON object keys are rewritten when encoding request
+// bodies and decoding responses, for APIs whose naming doesn't match Go
+// field names. With a style set, struct fields don't need json tags. Only
+// the keys of untagged struct fields are rewritten: fields with a json tag
+// and the keys of maps are sent and matched as they are.
+type KeyStyle int
+
+const (
+	// NoKeyMapping leaves keys as produced by encoding/json (the default).
+	NoKeyMapping KeyStyle = iota
+	SnakeCase
+	CamelCase
+)
+
+func (c *Client) SetKeyMapping(style KeyStyle) {
+	c.keyStyle = style
+}
+
+// splitWords breaks a key such as "userID", "UserId" or "user_id" into
+// its words.
+func splitWords(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := 0
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		if r == '_' || r == '-' || r == ' ' {
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+
+			start = i + 1
+			continue
+		}
+
+		if i > start && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+	}
+
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+
+	return words
+}
+
+func title(s string) string {
+	runes := []rune(strings.ToLower(s))
+
+	if len(runes) > 0 {
+		runes[0] = unicode.ToUpper(runes[0])
+	}
+
+	return string(runes)
+}
+
+func (s KeyStyle) convert(key string) string {
+	words := splitWords(key)
+
+	for i, w := range words {
+		switch {
+		case s == SnakeCase, s == CamelCase && i == 0:
+			words[i] = strings.ToLower(w)
+		default:
+			words[i] = title(w)
+		}
+	}
+
+	if s == SnakeCase {
+		return strings.Join(words, "_")
+	}
+
+	return strings.Join(words, "")
+}
+
+// toGoKey turns any key style into PascalCase, which encoding/json matches
+// case-insensitively against struct field names.
+func toGoKey(key string) string {
+	words := splitWords(key)
+
+	for i, w := range words {
+		words[i] = title(w)
+	}
+
+	return strings.Join(words, "")
+}
+
+var (
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// jsonField is a struct field as encoding/json sees it.
+type jsonField struct {
+	name   string
+	tagged bool
+	typ    reflect.Type
+}
+
+// jsonFields lists the fields of struct type t, including those promoted
+// from embedded structs.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, jsonFields(ft)...)
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			fields = append(fields, jsonField{name: f.Name, typ: f.Type})
+		} else {
+			fields = append(fields, jsonField{name: name, tagged: true, typ: f.Type})
+		}
+	}
+
+	return fields
+}
+
+// encodeKey maps the key of an untagged field to the style.
+func (s KeyStyle) encodeKey(key string, fields []jsonField) (string, reflect.Type) {
+	for _, f := range fields {
+		if f.name == key {
+			if f.tagged {
+				return key, f.typ
+			}
+
+			return s.convert(key), f.typ
+		}
+	}
+
+	return key, nil
+}
+
+// decodeKey maps a key in any style back to the name of the untagged field
+// it stands for. Keys of tagged fields are left to encoding/json.
+func decodeKey(key string, fields []jsonField) (string, reflect.Type) {
+	for _, f := range fields {
+		if f.tagged && strings.EqualFold(f.name, key) {
+			return key, f.typ
+		}
+	}
+
+	goKey := toGoKey(key)
+
+	for _, f := range fields {
+		if !f.tagged && strings.EqualFold(f.name, goKey) {
+			return f.name, f.typ
+		}
+	}
+
+	return key, nil
+}
+
+// remapKeys renames, with rename, the keys of the JSON objects in v that
+// stand for struct fields of type t. Map keys are left alone, and so are
+// values of types with their own JSON encoding or that t doesn't describe,
+// such as those behind interfaces.
+func remapKeys(v interface{}, t reflect.Type, rename func(string, []jsonField) (string, reflect.Type)) interface{} {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return v
+	}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(t)
+			m := make(map[string]interface{}, len(val))
+
+			for k, item := range val {
+				key, ft := rename(k, fields)
+				m[key] = remapKeys(item, ft, rename)
+			}
+
+			return m
+		case reflect.Map:
+			for k, item := range val {
+				val[k] = remapKeys(item, t.Elem(), rename)
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, item := range val {
+				val[i] = remapKeys(item, t.Elem(), rename)
+			}
+		}
+	}
+
+	return v
+}
+
+func remapJSON(data []byte, t reflect.Type, rename func(string, []jsonField) (string, reflect.Type)) ([]byte, error) {
+	var v interface{}
+
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(remapKeys(v, t, rename))
+}
+
+func (r *Request) encodeJSON(v interface{}) ([]byte, error) {
+	data, err := r.jsonCodec().marshal(v)
+
+	if err != nil || r.client.keyStyle == NoKeyMapping {
+		return data, err
+	}
+
+	return remapJSON(data, reflect.TypeOf(v), r.client.keyStyle.encodeKey)
+}
+
+// decodeJSON decodes data into v. An empty body, as sent with HEAD
+// responses and 204s, leaves v untouched instead of failing.
+func (r *Request) decodeJSON(data []byte, v interface{}) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+
+	if r.client.keyStyle != NoKeyMapping {
+		mapped, err := remapJSON(data, reflect.TypeOf(v), decodeKey)
+
+		if err != nil {
+			return newJSONError(data, err)
+		}
+
+		data = mapped
+	}
+
+	if err := r.jsonCodec().unmarshal(data, v); err != nil {
+		return newJSONError(data, err)
+	}
+
+	return nil
+}
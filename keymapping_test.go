@@ -0,0 +1,122 @@
+package gors
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSnakeCaseKeyMapping(t *testing.T) {
+	type user struct {
+		UserID    int
+		FirstName string
+		HTTPCode  int
+	}
+
+	var sent map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"user_id":2,"first_name":"bob","http_code":201}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetKeyMapping(SnakeCase)
+
+	r := c.NewRequest(POST, "/users")
+
+	if err := r.SetJSONBody(user{UserID: 1, FirstName: "alice", HTTPCode: 200}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := SendWithJSONResponse[user](r)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"user_id", "first_name", "http_code"} {
+		if _, ok := sent[key]; !ok {
+			t.Errorf("request body %v lacks key %q", sent, key)
+		}
+	}
+
+	if want := (user{UserID: 2, FirstName: "bob", HTTPCode: 201}); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestKeyStyleConvert(t *testing.T) {
+	tests := []struct {
+		style KeyStyle
+		in    string
+		want  string
+	}{
+		{SnakeCase, "UserID", "user_id"},
+		{SnakeCase, "HTTPCode", "http_code"},
+		{CamelCase, "HTTPCode", "httpCode"},
+		{CamelCase, "user_id", "userId"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.style.convert(tt.in); got != tt.want {
+			t.Errorf("convert(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestKeyMappingLeavesMapKeysAndTags(t *testing.T) {
+	type profile struct {
+		Owner    int    `json:"user_id"`
+		NickName string `json:"nickName"`
+		Labels   map[string]string
+		Friends  []struct{ FirstName string }
+	}
+
+	var sent map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"user_id":7,"nickName":"b","labels":{"en_us":"Hi","deFR":"Salut"},"friends":[{"first_name":"bob"}]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetKeyMapping(SnakeCase)
+
+	r := c.NewRequest(POST, "/")
+	in := profile{Owner: 1, NickName: "a", Labels: map[string]string{"en_us": "Hello", "deFR": "Hallo"}}
+	in.Friends = append(in.Friends, struct{ FirstName string }{"alice"})
+
+	if err := r.SetJSONBody(in); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := SendWithJSONResponse[profile](r)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	labels, _ := sent["labels"].(map[string]interface{})
+
+	if sent["user_id"] == nil || sent["nickName"] != "a" || labels["en_us"] != "Hello" || labels["deFR"] != "Hallo" {
+		t.Fatalf("got request body %v, want the tag and map keys unchanged", sent)
+	}
+
+	if friends, _ := sent["friends"].([]interface{}); len(friends) != 1 || friends[0].(map[string]interface{})["first_name"] != "alice" {
+		t.Fatalf("got friends %v, want first_name", sent["friends"])
+	}
+
+	if got.Owner != 7 || got.NickName != "b" {
+		t.Fatalf("got %+v, want the tagged fields decoded", got)
+	}
+
+	if got.Labels["en_us"] != "Hi" || got.Labels["deFR"] != "Salut" {
+		t.Fatalf("got labels %v, want the map keys unchanged", got.Labels)
+	}
+
+	if len(got.Friends) != 1 || got.Friends[0].FirstName != "bob" {
+		t.Fatalf("got friends %+v, want bob", got.Friends)
+	}
+}
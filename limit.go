@@ -0,0 +1,76 @@
+package gors
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	ErrResponseTooLarge = errors.New("response body exceeds the size limit")
+	ErrDownloadTooLarge = errors.New("download exceeds the byte budget")
+)
+
+// SetMaxResponseBytes limits the size of response bodies read by the
+// buffered helpers; larger bodies fail with ErrResponseTooLarge. Zero means
+// no limit.
+func (c *Client) SetMaxResponseBytes(n int64) {
+	c.maxResponseBytes = n
+}
+
+// SetMaxResponseBytes overrides the client's response size limit for this
+// request, e.g. to allow one large download.
+func (r *Request) SetMaxResponseBytes(n int64) {
+	r.maxResponseBytes = &n
+}
+
+func (r *Request) responseLimit() int64 {
+	if r.maxResponseBytes != nil {
+		return *r.maxResponseBytes
+	}
+
+	return r.client.maxResponseBytes
+}
+
+// SetMaxDownloadBytes sets a byte budget for the streaming helpers, such as
+// Stream and SendJSONReader: reading past n bytes of the body fails with
+// ErrDownloadTooLarge, whether or not the server sent a Content-Length.
+func (r *Request) SetMaxDownloadBytes(n int64) {
+	r.maxDownloadBytes = n
+}
+
+// downloadLimiter reads at most left bytes and fails once more arrive.
+type downloadLimiter struct {
+	io.ReadCloser
+	left int64
+}
+
+func (l *downloadLimiter) Read(p []byte) (int, error) {
+	if l.left <= 0 {
+		var probe [1]byte
+		n, err := l.ReadCloser.Read(probe[:])
+
+		if n > 0 {
+			return 0, ErrDownloadTooLarge
+		}
+
+		return 0, err
+	}
+
+	if int64(len(p)) > l.left {
+		p = p[:l.left]
+	}
+
+	n, err := l.ReadCloser.Read(p)
+	l.left -= int64(n)
+
+	return n, err
+}
+
+// limitDownload applies the download budget to the response body.
+func (r *Request) limitDownload(body io.ReadCloser) io.ReadCloser {
+	if r.maxDownloadBytes <= 0 {
+		return body
+	}
+
+	return &downloadLimiter{ReadCloser: body, left: r.maxDownloadBytes}
+}
@@ -0,0 +1,81 @@
+package gors
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSetMaxResponseBytes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("x", 100)))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetMaxResponseBytes(10)
+
+	if _, err := c.NewRequest(GET, "/").Send(); !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("got %v, want ErrResponseTooLarge under the client limit", err)
+	}
+
+	r := c.NewRequest(GET, "/")
+	r.SetMaxResponseBytes(1000)
+
+	res, err := r.Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.Body) != 100 {
+		t.Fatalf("got %d bytes, want 100", len(res.Body))
+	}
+}
+
+func TestSetMaxDownloadBytes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Flushing first drops the Content-Length, so only the budget stops the read.
+		w.(http.Flusher).Flush()
+		w.Write(bytes.Repeat([]byte("x"), 5000))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	var read int64
+	r := c.NewRequest(GET, "/")
+	r.SetMaxDownloadBytes(1000)
+
+	_, err := r.Stream(func(body io.Reader) error {
+		n, err := io.Copy(io.Discard, body)
+		read = n
+
+		return err
+	})
+
+	if !errors.Is(err, ErrDownloadTooLarge) {
+		t.Fatalf("got %v, want ErrDownloadTooLarge", err)
+	}
+
+	if read != 1000 {
+		t.Fatalf("got %d bytes, want 1000", read)
+	}
+
+	r = c.NewRequest(GET, "/")
+	r.SetMaxDownloadBytes(5000)
+
+	_, err = r.Stream(func(body io.Reader) error {
+		_, err := io.Copy(io.Discard, body)
+
+		return err
+	})
+
+	if err != nil {
+		t.Fatalf("a body of exactly the budget failed: %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package gors
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type memoEntry struct {
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+type responseMemo struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]memoEntry
+}
+
+// EnableResponseMemo makes the buffered helpers remember 2xx responses to
+// GET requests for ttl, keyed by URL, and answer identical GETs from memory
+// in that time. Cache headers are ignored, and so are request headers: use
+// it only where every caller may see the same response. Like the headers
+// of AddDefaultHeader, the memo and its ttl are shared by all copies of a
+// client created with NewClient: enabling it again on any copy replaces it,
+// empty, for all of them, and a ttl of zero turns it off.
+func (c *Client) EnableResponseMemo(ttl time.Duration) {
+	if c.shared == nil {
+		c.shared = &sharedState{}
+	}
+
+	c.shared.memo = &responseMemo{ttl: ttl, entries: make(map[string]memoEntry)}
+}
+
+// memoKey returns the key the response to r is remembered under, or false
+// if it isn't memoized.
+func (r *Request) memoKey() (string, bool) {
+	if r.client.shared == nil || r.client.shared.memo == nil || r.client.shared.memo.ttl <= 0 || r.Method != GET {
+		return "", false
+	}
+
+	u, err := r.BuildURL()
+
+	if err != nil {
+		return "", false
+	}
+
+	return u.String(), true
+}
+
+func (r *Request) memoLookup(key string) ([]byte, *http.Response, bool) {
+	m := r.client.shared.memo
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+
+	if !ok {
+		return nil, nil, false
+	}
+
+	if time.Now().After(e.expires) {
+		delete(m.entries, key)
+		return nil, nil, false
+	}
+
+	res := &http.Response{
+		StatusCode: e.status,
+		Status:     fmt.Sprintf("%d %s", e.status, http.StatusText(e.status)),
+		Header:     e.header.Clone(),
+		Body:       http.NoBody,
+	}
+
+	return append([]byte(nil), e.body...), res, true
+}
+
+func (r *Request) memoStore(key string, body []byte, res *http.Response) {
+	m := r.client.shared.memo
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+
+	for k, e := range m.entries {
+		if now.After(e.expires) {
+			delete(m.entries, k)
+		}
+	}
+
+	m.entries[key] = memoEntry{
+		status:  res.StatusCode,
+		header:  res.Header.Clone(),
+		body:    append([]byte(nil), body...),
+		expires: now.Add(m.ttl),
+	}
+}
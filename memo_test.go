@@ -0,0 +1,80 @@
+package gors
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEnableResponseMemo(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"n":%d}`, atomic.AddInt32(&calls, 1))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.EnableResponseMemo(100 * time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		v, err := SendWithJSONResponse[map[string]int](c.NewRequest(GET, "/x"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if v["n"] != 1 {
+			t.Fatalf("GET %d: got %v, want the memoized first response", i, v)
+		}
+	}
+
+	// Other URLs and methods go to the server.
+	if _, err := c.NewRequest(GET, "/y").Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.NewRequest(POST, "/x").Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := atomic.LoadInt32(&calls); n != 3 {
+		t.Fatalf("server saw %d requests, want 3", n)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	v, err := SendWithJSONResponse[map[string]int](c.NewRequest(GET, "/x"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v["n"] != 4 {
+		t.Fatalf("got %v after the ttl, want a fresh response", v)
+	}
+}
+
+func TestCopiesShareResponseMemo(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	copied := c
+	copied.EnableResponseMemo(time.Minute)
+
+	for _, client := range []Client{c, copied} {
+		if _, err := client.NewRequest(GET, "/").Send(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Fatalf("server saw %d requests, want 1", n)
+	}
+}
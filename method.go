@@ -0,0 +1,57 @@
+package gors
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnknownMethod  = errors.New("unknown HTTP method")
+	ErrBodyNotAllowed = errors.New("request body not allowed")
+)
+
+var knownMethods = map[string]bool{
+	GET:     true,
+	POST:    true,
+	PUT:     true,
+	DELETE:  true,
+	HEAD:    true,
+	PATCH:   true,
+	OPTIONS: true,
+	TRACE:   true,
+	CONNECT: true,
+}
+
+// bodylessMethods are the methods that must not carry a request body. GET
+// and HEAD bodies have no defined meaning but are allowed, since APIs such
+// as Elasticsearch's _search rely on them.
+var bodylessMethods = map[string]bool{
+	TRACE:   true,
+	CONNECT: true,
+}
+
+// AllowCustomMethods lets requests use methods other than the standard
+// verbs, e.g. WebDAV's PROPFIND. By default they are rejected to catch
+// typos such as "GTE".
+func (c *Client) AllowCustomMethods(allow bool) {
+	c.customMethods = allow
+}
+
+// Validate checks that the method is known, unless custom methods are
+// allowed, that TRACE and CONNECT have no body and that the body
+// matches the JSON schema, if one is set. It runs before every send.
+func (r *Request) Validate() error {
+	if !knownMethods[r.Method] && !r.client.customMethods {
+		return fmt.Errorf("%w %q", ErrUnknownMethod, r.Method)
+	}
+
+	if bodylessMethods[r.Method] && r.hasBody() {
+		return fmt.Errorf("%w for %s", ErrBodyNotAllowed, r.Method)
+	}
+
+	if r.schema != nil && len(r.Body) > 0 {
+		return r.schema.validate(r.Body)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package gors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidateMethod(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Method))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	if _, err := c.NewRequest("GTE", "/").Send(); !errors.Is(err, ErrUnknownMethod) {
+		t.Fatalf("got %v, want ErrUnknownMethod for a typo", err)
+	}
+
+	c.AllowCustomMethods(true)
+
+	res, err := c.NewRequest("PROPFIND", "/").Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(res.Body) != "PROPFIND" {
+		t.Fatalf("server saw method %q, want PROPFIND", res.Body)
+	}
+}
+
+func TestValidateBody(t *testing.T) {
+	c := NewClient("http://example.com")
+
+	r := c.NewRequest(TRACE, "/")
+	r.SetBody([]byte("x"))
+
+	if err := r.Validate(); !errors.Is(err, ErrBodyNotAllowed) {
+		t.Fatalf("got %v, want ErrBodyNotAllowed for TRACE", err)
+	}
+
+	// Some APIs, such as Elasticsearch's search, take a GET body.
+	r = c.NewRequest(GET, "/_search")
+	r.SetBody([]byte(`{"query":{}}`))
+
+	if err := r.Validate(); err != nil {
+		t.Fatalf("got %v for a GET body", err)
+	}
+}
+
+func TestTraceAndConnect(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s %s %s %d", r.Method, r.RequestURI, r.Host, r.ContentLength)
+	}))
+	defer srv.Close()
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+	c := NewClient(srv.URL)
+
+	res, err := c.NewRequest(TRACE, "/a").Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "TRACE /a " + host + " 0"; string(res.Body) != want {
+		t.Fatalf("got %q, want %q", res.Body, want)
+	}
+
+	// CONNECT targets the host itself; the path isn't sent.
+	res, err = c.NewRequest(CONNECT, "/ignored").Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "CONNECT " + host + " " + host + " 0"; string(res.Body) != want {
+		t.Fatalf("got %q, want %q", res.Body, want)
+	}
+
+	r := c.NewRequest(TRACE, "/a")
+	r.SetBody([]byte("x"))
+
+	if _, err := r.Send(); !errors.Is(err, ErrBodyNotAllowed) {
+		t.Fatalf("got %v, want ErrBodyNotAllowed", err)
+	}
+}
@@ -0,0 +1,79 @@
+package gors
+
+import "time"
+
+// RequestMetrics describes one attempt at sending a request.
+type RequestMetrics struct {
+	Method string
+	Path   string
+
+	// Operation is the name set with SetOperationName, or else Path. It
+	// is the better label, since paths often contain IDs.
+	Operation string
+
+	// Status is zero when the attempt failed with Err.
+	Status int
+	Err    error
+
+	// Latency runs from sending the request until its response body was
+	// closed, so it includes reading the body.
+	Latency time.Duration
+
+	// SLA is the latency set with SetLatencySLA, or zero; SLAViolated
+	// reports whether Latency exceeded it.
+	SLA         time.Duration
+	SLAViolated bool
+}
+
+// SetMetricsObserver sets a function called after every attempt, e.g. to
+// feed a metrics system.
+func (c *Client) SetMetricsObserver(fn func(RequestMetrics)) {
+	c.metricsObserver = fn
+}
+
+// SetLatencySLA sets the latency the request is expected to stay within.
+// Attempts exceeding it are reported as SLA violations to the metrics
+// observer.
+func (r *Request) SetLatencySLA(d time.Duration) {
+	r.latencySLA = d
+}
+
+// SetOperationName names what the request does, e.g. "GetUser", for
+// metrics and debug logs. Unlike the path it stays the same whatever IDs
+// the path contains.
+func (r *Request) SetOperationName(name string) {
+	r.operationName = name
+}
+
+func (r *Request) operation() string {
+	if r.operationName != "" {
+		return r.operationName
+	}
+
+	return r.Path
+}
+
+// LastLatency returns the latency of the request's most recent attempt
+// that has ended.
+func (r *Request) LastLatency() time.Duration {
+	return r.lastLatency
+}
+
+func (r *Request) observe(latency time.Duration, status int, err error) {
+	r.lastLatency = latency
+
+	if r.client.metricsObserver == nil {
+		return
+	}
+
+	r.client.metricsObserver(RequestMetrics{
+		Method:      r.Method,
+		Path:        r.Path,
+		Operation:   r.operation(),
+		Status:      status,
+		Err:         err,
+		Latency:     latency,
+		SLA:         r.latencySLA,
+		SLAViolated: r.latencySLA > 0 && latency > r.latencySLA,
+	})
+}
@@ -0,0 +1,91 @@
+package gors
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetLatencySLA(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(100 * time.Millisecond)
+		}
+	}))
+	defer srv.Close()
+
+	var metrics []RequestMetrics
+	c := NewClient(srv.URL)
+	c.SetMetricsObserver(func(m RequestMetrics) { metrics = append(metrics, m) })
+
+	r := c.NewRequest(GET, "/slow")
+	r.SetLatencySLA(20 * time.Millisecond)
+
+	if _, err := r.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r.LastLatency() < 100*time.Millisecond {
+		t.Fatalf("got latency %v, want at least 100ms", r.LastLatency())
+	}
+
+	if len(metrics) != 1 || !metrics[0].SLAViolated || metrics[0].Status != http.StatusOK {
+		t.Fatalf("got %+v, want one violated 200", metrics)
+	}
+
+	r = c.NewRequest(GET, "/fast")
+	r.SetLatencySLA(time.Second)
+
+	if _, err := r.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(metrics) != 2 || metrics[1].SLAViolated || metrics[1].Latency != r.LastLatency() {
+		t.Fatalf("got %+v, want a second report within the SLA", metrics)
+	}
+}
+
+func TestSetOperationName(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	var (
+		metrics []RequestMetrics
+		log     bytes.Buffer
+	)
+
+	c := NewClient(srv.URL)
+	c.SetMetricsObserver(func(m RequestMetrics) { metrics = append(metrics, m) })
+	c.SetLogger(&log)
+
+	r := c.NewRequest(GET, "/users/123")
+	r.SetOperationName("GetUser")
+	r.SetDebug(true)
+
+	if _, err := r.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.NewRequest(GET, "/users/5").Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(metrics) != 2 {
+		t.Fatalf("got %d reports, want 2", len(metrics))
+	}
+
+	if metrics[0].Operation != "GetUser" || metrics[0].Path != "/users/123" {
+		t.Fatalf("got %+v, want operation GetUser on /users/123", metrics[0])
+	}
+
+	if metrics[1].Operation != "/users/5" {
+		t.Fatalf("got operation %q, want the path as fallback", metrics[1].Operation)
+	}
+
+	if !strings.Contains(log.String(), "operation GetUser") {
+		t.Fatalf("operation missing from debug log:\n%s", log.String())
+	}
+}
@@ -0,0 +1,64 @@
+package gors
+
+import "net/http"
+
+// Middleware wraps the round tripper that sends a request, so it can change
+// the outgoing request or the response.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to http.RoundTripper, which is handy
+// when writing middleware.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type namedMiddleware struct {
+	name string
+	mw   Middleware
+}
+
+// Use registers a named middleware. Middleware registered first runs first.
+// The name lets individual requests opt out with SkipMiddleware.
+func (c *Client) Use(name string, mw Middleware) {
+	c.middleware = append(c.middleware, namedMiddleware{name: name, mw: mw})
+}
+
+// SkipMiddleware makes the request bypass the named middleware, or all
+// middleware when called without names. This is useful e.g. for a token
+// refresh call that must not go through the auth middleware.
+func (r *Request) SkipMiddleware(names ...string) {
+	if len(names) == 0 {
+		r.skipAllMiddleware = true
+		return
+	}
+
+	if r.skipMiddleware == nil {
+		r.skipMiddleware = make(map[string]bool)
+	}
+
+	for _, name := range names {
+		r.skipMiddleware[name] = true
+	}
+}
+
+func (r *Request) roundTripper() http.RoundTripper {
+	rt := r.transport()
+
+	if r.client.wireDebug != nil {
+		rt = wireDebugTransport{next: rt, w: r.client.wireDebug}
+	}
+
+	if r.skipAllMiddleware {
+		return rt
+	}
+
+	for i := len(r.client.middleware) - 1; i >= 0; i-- {
+		if m := r.client.middleware[i]; !r.skipMiddleware[m.name] {
+			rt = m.mw(rt)
+		}
+	}
+
+	return rt
+}
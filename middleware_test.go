@@ -0,0 +1,53 @@
+package gors
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSkipMiddleware(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	c := NewClient(srv.URL)
+	c.Use("auth", record("auth"))
+	c.Use("log", record("log"))
+
+	tests := []struct {
+		skip []string
+		want string
+	}{
+		{nil, "[auth log]"},
+		{[]string{"auth"}, "[log]"},
+		{[]string{}, "[]"},
+	}
+
+	for _, tt := range tests {
+		calls = []string{}
+		r := c.NewRequest(GET, "/")
+
+		if tt.skip != nil {
+			r.SkipMiddleware(tt.skip...)
+		}
+
+		if _, err := r.Send(); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := fmt.Sprint(calls); got != tt.want {
+			t.Errorf("skipping %q: got calls %s, want %s", tt.skip, got, tt.want)
+		}
+	}
+}
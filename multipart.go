@@ -0,0 +1,150 @@
+package gors
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+// MultipartPart is a part of a multipart/form-data body: a field, or a
+// file if FileName is set. ContentType defaults to none for fields and to
+// application/octet-stream for files.
+type MultipartPart struct {
+	Name        string
+	FileName    string
+	ContentType string
+	Data        []byte
+}
+
+// SetMultipartStream sets a multipart/form-data body made of fields and a
+// single file read from file. The body is generated while it is being sent,
+// so large files are never held in memory. Since file can only be read
+// once, the request can only be sent once.
+func (r *Request) SetMultipartStream(fields map[string]string, fieldName, fileName string, file io.Reader) error {
+	if fieldName == "" {
+		return errors.New("multipart file field name is empty")
+	}
+
+	boundary := multipart.NewWriter(nil).Boundary()
+
+	r.Body = nil
+	r.bodyLength = -1
+	r.bodyReplayable = false
+	r.bodyStream = func() (io.ReadCloser, error) {
+		return &lazyPipe{write: func(pw *io.PipeWriter) {
+			mw := multipart.NewWriter(pw)
+			mw.SetBoundary(boundary)
+			pw.CloseWithError(writeMultipart(mw, fields, fieldName, fileName, file))
+		}}, nil
+	}
+
+	r.SetHeader("Content-Type", "multipart/form-data; boundary="+boundary)
+
+	return nil
+}
+
+// lazyPipe starts writing the body on the first Read, so no goroutine is
+// left blocked on the pipe if the request fails before the body is sent.
+type lazyPipe struct {
+	once  sync.Once
+	write func(pw *io.PipeWriter)
+	pr    *io.PipeReader
+}
+
+func (l *lazyPipe) Read(p []byte) (int, error) {
+	l.once.Do(func() {
+		pr, pw := io.Pipe()
+		l.pr = pr
+
+		go l.write(pw)
+	})
+
+	return l.pr.Read(p)
+}
+
+func (l *lazyPipe) Close() error {
+	l.once.Do(func() {
+		pr, pw := io.Pipe()
+		pw.Close()
+		l.pr = pr
+	})
+
+	return l.pr.Close()
+}
+
+func writeMultipart(mw *multipart.Writer, fields map[string]string, fieldName, fileName string, file io.Reader) error {
+	for k, v := range fields {
+		if err := mw.WriteField(k, v); err != nil {
+			return err
+		}
+	}
+
+	part, err := mw.CreateFormFile(fieldName, fileName)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(part, file); err != nil {
+		return err
+	}
+
+	return mw.Close()
+}
+
+// SetMultipartOrdered sets a multipart/form-data body with parts in the
+// given order, for servers and signatures that depend on it; maps such as
+// the fields of SetMultipartStream have no order.
+func (r *Request) SetMultipartOrdered(parts []MultipartPart) error {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	for _, p := range parts {
+		if p.Name == "" {
+			return errors.New("multipart field name is empty")
+		}
+
+		disposition := fmt.Sprintf(`form-data; name="%s"`, quoteEscaper.Replace(p.Name))
+		contentType := p.ContentType
+
+		if p.FileName != "" {
+			disposition += fmt.Sprintf(`; filename="%s"`, quoteEscaper.Replace(p.FileName))
+
+			if contentType == "" {
+				contentType = "application/octet-stream"
+			}
+		}
+
+		header := textproto.MIMEHeader{"Content-Disposition": {disposition}}
+
+		if contentType != "" {
+			header.Set("Content-Type", contentType)
+		}
+
+		part, err := mw.CreatePart(header)
+
+		if err != nil {
+			return err
+		}
+
+		if _, err := part.Write(p.Data); err != nil {
+			return err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return err
+	}
+
+	r.SetBody(buf.Bytes())
+	r.SetHeader("Content-Type", mw.FormDataContentType())
+
+	return nil
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
@@ -0,0 +1,154 @@
+package gors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// patternReader yields an endless repetition of the bytes 0 to 250, so
+// misplaced chunks change the content.
+type patternReader struct {
+	n int
+}
+
+func (p *patternReader) Read(b []byte) (int, error) {
+	for i := range b {
+		b[i] = byte(p.n % 251)
+		p.n++
+	}
+
+	return len(b), nil
+}
+
+func TestSetMultipartStream(t *testing.T) {
+	const size = 8 << 20
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mr, err := r.MultipartReader()
+
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		for {
+			part, err := mr.NextPart()
+
+			if err != nil {
+				break
+			}
+
+			switch part.FormName() {
+			case "title":
+				b, _ := io.ReadAll(part)
+				w.Write(append(b, '\n'))
+			case "file":
+				h := sha256.New()
+				io.Copy(h, part)
+				w.Write([]byte(part.FileName() + " " + hex.EncodeToString(h.Sum(nil))))
+			}
+		}
+	}))
+	defer srv.Close()
+
+	want := sha256.New()
+	io.Copy(want, io.LimitReader(&patternReader{}, size))
+
+	r := NewClient(srv.URL).NewRequest(POST, "/upload")
+
+	if err := r.SetMultipartStream(map[string]string{"title": "big"}, "file", "big.bin", io.LimitReader(&patternReader{}, size)); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := r.Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := string(res.Body); got != "big\nbig.bin "+hex.EncodeToString(want.Sum(nil)) {
+		t.Fatalf("server got %q", got)
+	}
+}
+
+func TestSetMultipartStreamNoLeakOnFailure(t *testing.T) {
+	c := NewClient("http://example.com")
+	c.AllowCustomMethods(true)
+
+	before := runtime.NumGoroutine()
+
+	// net/http rejects the method after the body was created, so the
+	// request fails without the body ever being read or closed.
+	for i := 0; i < 20; i++ {
+		r := c.NewRequest("BAD METHOD", "/upload")
+
+		if err := r.SetMultipartStream(nil, "file", "f.bin", strings.NewReader("data")); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := r.Send(); err == nil {
+			t.Fatal("sending with an invalid method succeeded")
+		}
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	if leaked := runtime.NumGoroutine() - before; leaked >= 10 {
+		t.Fatalf("%d goroutines left behind by failed uploads", leaked)
+	}
+}
+
+func TestSetMultipartOrdered(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mr, err := r.MultipartReader()
+
+		if err != nil {
+			t.Error(err)
+
+			return
+		}
+
+		for {
+			p, err := mr.NextPart()
+
+			if err != nil {
+				break
+			}
+
+			data, _ := io.ReadAll(p)
+			fmt.Fprintf(w, "%s=%s/%s/%s;", p.FormName(), data, p.FileName(), p.Header.Get("Content-Type"))
+		}
+	}))
+	defer srv.Close()
+
+	r := NewClient(srv.URL).NewRequest(POST, "/")
+
+	err := r.SetMultipartOrdered([]MultipartPart{
+		{Name: "z", Data: []byte("1")},
+		{Name: "a", Data: []byte("2")},
+		{Name: "f", FileName: `x"y.txt`, Data: []byte("3")},
+		{Name: "m", Data: []byte("4")},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := r.Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `z=1//;a=2//;f=3/x"y.txt/application/octet-stream;m=4//;`; string(res.Body) != want {
+		t.Fatalf("got %q, want %q", res.Body, want)
+	}
+}
@@ -0,0 +1,62 @@
+package gors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// Part is one part of a multipart response.
+type Part struct {
+	Header http.Header
+	Body   []byte
+}
+
+// ParseMultipartResponse reads a multipart/mixed or multipart/related
+// response, as returned by many batch APIs, into its parts, and closes the
+// body.
+func ParseMultipartResponse(res *http.Response) ([]Part, error) {
+	defer drainAndClose(res.Body)
+
+	mediaType, params, err := mime.ParseMediaType(res.Header.Get("Content-Type"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return nil, fmt.Errorf("expected multipart response, got %q", mediaType)
+	}
+
+	if params["boundary"] == "" {
+		return nil, errors.New("multipart response without boundary")
+	}
+
+	mr := multipart.NewReader(res.Body, params["boundary"])
+	var parts []Part
+
+	for {
+		p, err := mr.NextRawPart()
+
+		if err == io.EOF {
+			return parts, nil
+		}
+
+		if err != nil {
+			return parts, err
+		}
+
+		body, err := io.ReadAll(p)
+		p.Close()
+
+		if err != nil {
+			return parts, err
+		}
+
+		parts = append(parts, Part{Header: http.Header(p.Header), Body: body})
+	}
+}
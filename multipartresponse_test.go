@@ -0,0 +1,56 @@
+package gors
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseMultipartResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "multipart/mixed; boundary=batch")
+		w.Write([]byte("--batch\r\n" +
+			"Content-Type: application/json\r\n\r\n" +
+			"{\"id\":1}\r\n" +
+			"--batch\r\n" +
+			"Content-Type: text/plain\r\n\r\n" +
+			"hello\r\n" +
+			"--batch--\r\n"))
+	}))
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts, err := ParseMultipartResponse(res)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(parts) != 2 {
+		t.Fatalf("got %d parts, want 2", len(parts))
+	}
+
+	if parts[0].Header.Get("Content-Type") != "application/json" || string(parts[0].Body) != `{"id":1}` {
+		t.Errorf("first part: got %v %q", parts[0].Header, parts[0].Body)
+	}
+
+	if parts[1].Header.Get("Content-Type") != "text/plain" || string(parts[1].Body) != "hello" {
+		t.Errorf("second part: got %v %q", parts[1].Header, parts[1].Body)
+	}
+}
+
+func TestParseMultipartResponseNotMultipart(t *testing.T) {
+	res := responseWithHeader("Content-Type", "application/json")
+	res.Body = io.NopCloser(strings.NewReader(`{"id":1}`))
+
+	if _, err := ParseMultipartResponse(res); err == nil {
+		t.Fatal("expected an error for a non-multipart response")
+	}
+}
@@ -0,0 +1,66 @@
+package gors
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSetOAuth2ClientCredentials(t *testing.T) {
+	var fetches int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/token" {
+			w.Write([]byte(r.Header.Get("Authorization")))
+			return
+		}
+
+		id, secret, _ := r.BasicAuth()
+		secret, _ = url.QueryUnescape(secret)
+		r.ParseForm()
+
+		if id != "client" || secret != "s3cret value" || r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("scope") != "read write" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		n := atomic.AddInt32(&fetches, 1)
+		fmt.Fprintf(w, `{"access_token":"token%d","token_type":"bearer","expires_in":1}`, n)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetOAuth2ClientCredentials(srv.URL+"/token", "client", "s3cret value", []string{"read", "write"})
+
+	for i := 0; i < 2; i++ {
+		res, err := c.NewRequest(GET, "/resource").Send()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(res.Body) != "Bearer token1" {
+			t.Fatalf("request %d sent %q, want the cached token1", i, res.Body)
+		}
+	}
+
+	// The token is refreshed once 90% of its one second lifetime is over.
+	time.Sleep(950 * time.Millisecond)
+
+	res, err := c.NewRequest(GET, "/resource").Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(res.Body) != "Bearer token2" {
+		t.Fatalf("got %q after expiry, want the refreshed token2", res.Body)
+	}
+
+	if n := atomic.LoadInt32(&fetches); n != 2 {
+		t.Fatalf("token endpoint was called %d times, want 2", n)
+	}
+}
@@ -0,0 +1,125 @@
+package gors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// OrderedMap is a JSON object that remembers the order of its keys. Nested
+// objects are *OrderedMap as well; other values decode as with
+// encoding/json into interface{}.
+type OrderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// Keys returns the keys in document order.
+func (m *OrderedMap) Keys() []string {
+	return append([]string(nil), m.keys...)
+}
+
+func (m *OrderedMap) Get(key string) (interface{}, bool) {
+	v, ok := m.values[key]
+	return v, ok
+}
+
+func (m *OrderedMap) Len() int {
+	return len(m.keys)
+}
+
+// SendWithOrderedJSON decodes a JSON object response keeping the order of
+// its keys. An empty body, as sent with 204s, gives an empty map.
+func SendWithOrderedJSON(r *Request) (*OrderedMap, *http.Response, error) {
+	body, res, err := r.sendBuffered(context.Background())
+
+	if err != nil {
+		return nil, res, err
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return &OrderedMap{}, res, nil
+	}
+
+	d := json.NewDecoder(bytes.NewReader(body))
+	tok, err := d.Token()
+
+	if err != nil {
+		return nil, res, err
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, res, fmt.Errorf("expected JSON object, got %v", tok)
+	}
+
+	m, err := decodeOrderedObject(d)
+
+	return m, res, err
+}
+
+// decodeOrderedObject decodes an object whose opening brace has been read.
+func decodeOrderedObject(d *json.Decoder) (*OrderedMap, error) {
+	m := OrderedMap{values: make(map[string]interface{})}
+
+	for d.More() {
+		tok, err := d.Token()
+
+		if err != nil {
+			return nil, err
+		}
+
+		key := tok.(string)
+		value, err := decodeOrderedValue(d)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if _, dup := m.values[key]; !dup {
+			m.keys = append(m.keys, key)
+		}
+
+		m.values[key] = value
+	}
+
+	if _, err := d.Token(); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func decodeOrderedValue(d *json.Decoder) (interface{}, error) {
+	tok, err := d.Token()
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		return decodeOrderedObject(d)
+	case json.Delim('['):
+		var values []interface{}
+
+		for d.More() {
+			v, err := decodeOrderedValue(d)
+
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, v)
+		}
+
+		if _, err := d.Token(); err != nil {
+			return nil, err
+		}
+
+		return values, nil
+	default:
+		return tok, nil
+	}
+}
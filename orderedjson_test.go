@@ -0,0 +1,53 @@
+package gors
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendWithOrderedJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"zeta":1,"alpha":{"yank":[1,{"q":2}],"bravo":null},"mike":"s"}`))
+	}))
+	defer srv.Close()
+
+	m, _, err := SendWithOrderedJSON(NewClient(srv.URL).NewRequest(GET, "/"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := fmt.Sprint(m.Keys()); got != "[zeta alpha mike]" {
+		t.Fatalf("got keys %s, want [zeta alpha mike]", got)
+	}
+
+	alpha, _ := m.Get("alpha")
+	nested, ok := alpha.(*OrderedMap)
+
+	if !ok {
+		t.Fatalf("got %T for a nested object, want *OrderedMap", alpha)
+	}
+
+	if got := fmt.Sprint(nested.Keys()); got != "[yank bravo]" {
+		t.Fatalf("got nested keys %s, want [yank bravo]", got)
+	}
+}
+
+func TestSendWithOrderedJSONEmptyBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	m, _, err := SendWithOrderedJSON(NewClient(srv.URL).NewRequest(GET, "/"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m == nil || m.Len() != 0 {
+		t.Fatalf("got %v, want an empty map", m)
+	}
+}
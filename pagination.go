@@ -0,0 +1,137 @@
+package gors
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// PaginateCursor follows cursor-based pagination. It sends r, passes each
+// page to extract, and sends the next request with cursorParam set to the
+// cursor extract returned, until the cursor is empty. The items of all
+// pages are returned together. A non-2xx page stops with a *StatusError.
+func PaginateCursor[T any](r *Request, cursorParam string, extract func(page Response) (items []T, nextCursor string)) ([]T, error) {
+	var all []T
+	it := NewPageIterator(r, cursorParam, extract)
+
+	for {
+		items, ok := it.Next()
+
+		if !ok {
+			return all, it.Err()
+		}
+
+		all = append(all, items...)
+	}
+}
+
+// PageIterator walks cursor-based pagination one page at a time, like
+// PaginateCursor.
+type PageIterator[T any] struct {
+	base        *Request
+	next        *Request
+	cursorParam string
+	extract     func(page Response) (items []T, nextCursor string)
+	seen        map[string]bool
+	err         error
+}
+
+// NewPageIterator returns an iterator whose first page is fetched with r.
+func NewPageIterator[T any](r *Request, cursorParam string, extract func(page Response) (items []T, nextCursor string)) *PageIterator[T] {
+	return &PageIterator[T]{
+		base:        r,
+		next:        r.clone(),
+		cursorParam: cursorParam,
+		extract:     extract,
+		seen:        make(map[string]bool),
+	}
+}
+
+// Peek returns the request Next will send, without sending it, so it can be
+// inspected or changed. It returns false once the iterator is done.
+func (it *PageIterator[T]) Peek() (*Request, bool) {
+	return it.next, it.next != nil
+}
+
+// Next sends the next request and returns the items of its page. It
+// returns false when there are no more pages or an error occurred; Err
+// tells the two apart.
+func (it *PageIterator[T]) Next() ([]T, bool) {
+	if it.next == nil {
+		return nil, false
+	}
+
+	request := it.next
+	it.next = nil
+
+	body, res, err := request.sendBuffered(context.Background())
+
+	if err != nil {
+		it.err = err
+		return nil, false
+	}
+
+	if !isSuccess(res.StatusCode) {
+		it.err = newStatusError(res.StatusCode, body)
+		return nil, false
+	}
+
+	items, cursor := it.extract(Response{Code: res.StatusCode, Body: body})
+
+	if cursor == "" {
+		return items, true
+	}
+
+	if it.seen[cursor] {
+		it.err = fmt.Errorf("pagination cursor %q repeated", cursor)
+		return items, true
+	}
+
+	it.seen[cursor] = true
+	it.next = it.base.clone()
+	it.next.SetQuery(it.cursorParam, cursor)
+
+	return items, true
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *PageIterator[T]) Err() error {
+	return it.err
+}
+
+// PageInfo holds pagination details sent in response headers. Fields whose
+// header is missing are zero.
+type PageInfo struct {
+	Page       int
+	PerPage    int
+	TotalPages int
+	TotalCount int
+}
+
+func headerInt(res *http.Response, key string) int {
+	n, _ := strconv.Atoi(strings.TrimSpace(res.Header.Get(key)))
+	return n
+}
+
+// SendPage decodes a JSON array response along with the X-Page,
+// X-Per-Page, X-Total-Pages and X-Total-Count headers.
+func SendPage[T any](r *Request) (items []T, page PageInfo, res *http.Response, err error) {
+	body, res, err := r.sendBuffered(context.Background())
+
+	if err != nil {
+		return nil, page, res, err
+	}
+
+	page = PageInfo{
+		Page:       headerInt(res, "X-Page"),
+		PerPage:    headerInt(res, "X-Per-Page"),
+		TotalPages: headerInt(res, "X-Total-Pages"),
+		TotalCount: headerInt(res, "X-Total-Count"),
+	}
+
+	err = r.decodeJSON(body, &items)
+
+	return items, page, res, err
+}
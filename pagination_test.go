@@ -0,0 +1,122 @@
+package gors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPaginateCursor(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"items":[1,2],"next":"c2"}`,
+		"c2": `{"items":[3,4],"next":"c3"}`,
+		"c3": `{"items":[5],"next":""}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "2" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Write([]byte(pages[r.URL.Query().Get("cursor")]))
+	}))
+	defer srv.Close()
+
+	r := NewClient(srv.URL).NewRequest(GET, "/items")
+	r.SetQuery("limit", 2)
+
+	items, err := PaginateCursor(r, "cursor", func(page Response) ([]int, string) {
+		var p struct {
+			Items []int
+			Next  string
+		}
+
+		json.Unmarshal(page.Body, &p)
+
+		return p.Items, p.Next
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := fmt.Sprint(items); got != "[1 2 3 4 5]" {
+		t.Fatalf("got %s, want [1 2 3 4 5]", got)
+	}
+}
+
+func TestSendPage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Page", "2")
+		w.Header().Set("X-Per-Page", "3")
+		w.Header().Set("X-Total-Pages", "4")
+		w.Header().Set("X-Total-Count", "11")
+		w.Write([]byte(`["d","e","f"]`))
+	}))
+	defer srv.Close()
+
+	items, page, _, err := SendPage[string](NewClient(srv.URL).NewRequest(GET, "/items"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(items) != "[d e f]" {
+		t.Fatalf("got items %v", items)
+	}
+
+	if want := (PageInfo{Page: 2, PerPage: 3, TotalPages: 4, TotalCount: 11}); page != want {
+		t.Fatalf("got %+v, want %+v", page, want)
+	}
+}
+
+func TestPageIteratorPeek(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			w.Write([]byte("a,c2"))
+		case "c2":
+			w.Write([]byte("b" + r.Header.Get("X-Tag") + ","))
+		}
+	}))
+	defer srv.Close()
+
+	extract := func(page Response) ([]string, string) {
+		parts := strings.Split(string(page.Body), ",")
+		return parts[:1], parts[1]
+	}
+
+	it := NewPageIterator(NewClient(srv.URL).NewRequest(GET, "/items"), "cursor", extract)
+
+	if items, ok := it.Next(); !ok || fmt.Sprint(items) != "[a]" {
+		t.Fatalf("got first page %v", items)
+	}
+
+	next, ok := it.Peek()
+	again, _ := it.Peek()
+
+	if !ok || next != again {
+		t.Fatal("Peek advanced the iterator")
+	}
+
+	if next.GetQuery()["cursor"] != "c2" {
+		t.Fatalf("peeked request has query %v, want cursor c2", next.GetQuery())
+	}
+
+	next.SetHeader("X-Tag", "!")
+
+	if items, ok := it.Next(); !ok || fmt.Sprint(items) != "[b!]" {
+		t.Fatalf("got second page %v, want it sent with the peeked request's header", items)
+	}
+
+	if _, ok := it.Peek(); ok {
+		t.Fatal("Peek returned a request after the last page")
+	}
+
+	if _, ok := it.Next(); ok || it.Err() != nil {
+		t.Fatalf("got another page or error %v after the last page", it.Err())
+	}
+}
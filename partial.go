@@ -0,0 +1,51 @@
+package gors
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// PartialDecode makes SendWithJSONResponse return whatever it managed to
+// decode along with the error, instead of a zero value. For a slice, every
+// element before the broken one is kept, e.g. from a truncated array.
+func (c *Client) PartialDecode(partial bool) {
+	c.partialDecode = partial
+}
+
+// decodePartial fills the slice v points to with the leading elements of
+// the JSON array in data that decode cleanly. Other types are left as the
+// failed decode left them.
+func (r *Request) decodePartial(data []byte, v interface{}) {
+	rv := reflect.ValueOf(v).Elem()
+
+	if rv.Kind() != reflect.Slice {
+		return
+	}
+
+	d := json.NewDecoder(bytes.NewReader(data))
+
+	if tok, err := d.Token(); err != nil || tok != json.Delim('[') {
+		return
+	}
+
+	items := reflect.MakeSlice(rv.Type(), 0, 0)
+
+	for d.More() {
+		var raw json.RawMessage
+
+		if err := d.Decode(&raw); err != nil {
+			break
+		}
+
+		item := reflect.New(rv.Type().Elem())
+
+		if err := r.decodeJSON(raw, item.Interface()); err != nil {
+			break
+		}
+
+		items = reflect.Append(items, item.Elem())
+	}
+
+	rv.Set(items)
+}
@@ -0,0 +1,36 @@
+package gors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPartialDecode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":1},{"id":2},{"id":`))
+	}))
+	defer srv.Close()
+
+	type item struct{ ID int }
+
+	c := NewClient(srv.URL)
+
+	v, err := SendWithJSONResponse[[]item](c.NewRequest(GET, "/"))
+
+	if err == nil || v != nil {
+		t.Fatalf("without partial decoding got %v, %v, want no items and an error", v, err)
+	}
+
+	c.PartialDecode(true)
+
+	v, err = SendWithJSONResponse[[]item](c.NewRequest(GET, "/"))
+
+	if err == nil {
+		t.Fatal("expected an error for the truncated array")
+	}
+
+	if len(v) != 2 || v[0].ID != 1 || v[1].ID != 2 {
+		t.Fatalf("got %v, want the two complete items", v)
+	}
+}
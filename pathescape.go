@@ -0,0 +1,23 @@
+package gors
+
+import (
+	"net/url"
+	"strings"
+)
+
+// EncodePathSegments escapes every segment of the request path with
+// url.PathEscape, so dynamic values can't introduce reserved characters
+// such as ';' or ','. Slashes still separate segments.
+func (c *Client) EncodePathSegments(encode bool) {
+	c.encodePathSegments = encode
+}
+
+func escapePathSegments(p string) string {
+	segments := strings.Split(p, "/")
+
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,35 @@
+package gors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEncodePathSegments(t *testing.T) {
+	c := NewClient("http://example.com/api v1")
+	path := "/files/my file#1;a,b.txt/"
+
+	c.EncodePathSegments(true)
+
+	u, err := c.NewRequest(GET, path).BuildURL()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "http://example.com/api%20v1/files/my%20file%231%3Ba%2Cb.txt/"; u.String() != want {
+		t.Fatalf("got %s, want %s", u, want)
+	}
+
+	c.EncodePathSegments(false)
+
+	u, err = c.NewRequest(GET, path).BuildURL()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(u.EscapedPath(), ";a,b.txt") {
+		t.Fatalf("without the flag got %s, want reserved characters left as they are", u)
+	}
+}
@@ -0,0 +1,161 @@
+package gors
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WaitOptions controls how WaitForStatus polls. Zero fields get defaults:
+// a 1s initial interval growing by a factor of 2 up to 30s, no jitter and
+// no overall timeout.
+type WaitOptions struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+
+	// Jitter randomizes each interval by up to this fraction in either
+	// direction, e.g. 0.2 for ±20%, so many waiting clients spread out.
+	Jitter float64
+
+	Timeout time.Duration
+}
+
+func (o WaitOptions) withDefaults() WaitOptions {
+	if o.InitialInterval <= 0 {
+		o.InitialInterval = time.Second
+	}
+
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = 30 * time.Second
+	}
+
+	if o.Multiplier < 1 {
+		o.Multiplier = 2
+	}
+
+	return o
+}
+
+// WaitForStatus polls r until predicate returns true for the decoded JSON
+// response, e.g. until a job reports it is done, and returns that value.
+// The interval between polls backs off as set in opts; a longer interval
+// asked for by the server in an X-Poll-Interval (in seconds) or Retry-After
+// header is honoured. A non-2xx response stops with a *StatusError;
+// when the timeout passes, the last value is returned with
+// context.DeadlineExceeded.
+func WaitForStatus[T any](r *Request, predicate func(T) bool, opts WaitOptions) (T, error) {
+	return poll(r, predicate, opts, nil)
+}
+
+// PollStep records one response seen while polling.
+type PollStep struct {
+	At     time.Time
+	Status int
+
+	// Body is the start of the response body, capped like StatusError.Body.
+	Body []byte
+}
+
+// PollVerbose is WaitForStatus that also returns every response it saw, so
+// the progress of a long-running operation can be logged.
+func PollVerbose[T any](r *Request, predicate func(T) bool, opts WaitOptions) (T, []PollStep, error) {
+	var steps []PollStep
+
+	v, err := poll(r, predicate, opts, func(step PollStep) {
+		steps = append(steps, step)
+	})
+
+	return v, steps, err
+}
+
+func poll[T any](r *Request, predicate func(T) bool, opts WaitOptions, record func(PollStep)) (T, error) {
+	opts = opts.withDefaults()
+	ctx := context.Background()
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	var last T
+	interval := opts.InitialInterval
+
+	for {
+		body, res, err := r.clone().sendBuffered(ctx)
+
+		if err != nil {
+			return last, err
+		}
+
+		if record != nil {
+			brief := body
+
+			if len(brief) > maxErrorBody {
+				brief = brief[:maxErrorBody]
+			}
+
+			record(PollStep{At: time.Now(), Status: res.StatusCode, Body: append([]byte(nil), brief...)})
+		}
+
+		if !isSuccess(res.StatusCode) {
+			return last, newStatusError(res.StatusCode, body)
+		}
+
+		var v T
+
+		if err := r.decodeJSON(body, &v); err != nil {
+			return last, err
+		}
+
+		if predicate(v) {
+			return v, nil
+		}
+
+		last = v
+		wait := jitter(interval, opts.Jitter)
+
+		if min, ok := serverPollInterval(res); ok && min > wait {
+			wait = min
+		}
+
+		t := time.NewTimer(wait)
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return last, ctx.Err()
+		}
+
+		interval = time.Duration(float64(interval) * opts.Multiplier)
+
+		if interval > opts.MaxInterval {
+			interval = opts.MaxInterval
+		}
+	}
+}
+
+func jitter(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 {
+		return d
+	}
+
+	return time.Duration(float64(d) * (1 + fraction*(2*rand.Float64()-1)))
+}
+
+// serverPollInterval returns the minimum poll interval the server asked
+// for, if any.
+func serverPollInterval(res *http.Response) (time.Duration, bool) {
+	if v := strings.TrimSpace(res.Header.Get("X-Poll-Interval")); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second, true
+		}
+	}
+
+	return RetryAfter(res)
+}
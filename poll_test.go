@@ -0,0 +1,164 @@
+package gors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type jobState struct {
+	State string `json:"state"`
+}
+
+func TestWaitForStatus(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) >= 3 {
+			w.Write([]byte(`{"state":"done"}`))
+			return
+		}
+
+		w.Write([]byte(`{"state":"pending"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	done := func(s jobState) bool { return s.State == "done" }
+
+	v, err := WaitForStatus(c.NewRequest(GET, "/jobs/1"), done, WaitOptions{
+		InitialInterval: 10 * time.Millisecond,
+		MaxInterval:     40 * time.Millisecond,
+		Multiplier:      1.5,
+		Jitter:          0.5,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.State != "done" || atomic.LoadInt32(&calls) != 3 {
+		t.Fatalf("got state %q after %d polls, want done after 3", v.State, calls)
+	}
+
+	atomic.StoreInt32(&calls, -100)
+
+	v, err = WaitForStatus(c.NewRequest(GET, "/jobs/1"), done, WaitOptions{
+		InitialInterval: 10 * time.Millisecond,
+		Timeout:         50 * time.Millisecond,
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+
+	if v.State != "pending" {
+		t.Fatalf("got state %q, want the last value seen", v.State)
+	}
+}
+
+func TestPollVerbose(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"state":"queued"}`))
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.Write([]byte(`{"state":"running"}`))
+		default:
+			w.Write([]byte(`{"state":"done"}`))
+		}
+	}))
+	defer srv.Close()
+
+	v, steps, err := PollVerbose(NewClient(srv.URL).NewRequest(GET, "/jobs/1"), func(s jobState) bool {
+		return s.State == "done"
+	}, WaitOptions{InitialInterval: 5 * time.Millisecond})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.State != "done" {
+		t.Fatalf("got state %q, want done", v.State)
+	}
+
+	want := []struct {
+		status int
+		body   string
+	}{
+		{http.StatusAccepted, `{"state":"queued"}`},
+		{http.StatusOK, `{"state":"running"}`},
+		{http.StatusOK, `{"state":"done"}`},
+	}
+
+	if len(steps) != len(want) {
+		t.Fatalf("got %d steps, want %d", len(steps), len(want))
+	}
+
+	for i, w := range want {
+		if steps[i].Status != w.status || string(steps[i].Body) != w.body {
+			t.Errorf("step %d: got %d %q, want %d %q", i, steps[i].Status, steps[i].Body, w.status, w.body)
+		}
+	}
+}
+
+func TestServerPollInterval(t *testing.T) {
+	tests := []struct {
+		header, value string
+		want          time.Duration
+		ok            bool
+	}{
+		{"X-Poll-Interval", "5", 5 * time.Second, true},
+		{"Retry-After", "2", 2 * time.Second, true},
+		{"X-Poll-Interval", "soon", 0, false},
+		{"", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		res := &http.Response{Header: http.Header{}}
+
+		if tt.header != "" {
+			res.Header.Set(tt.header, tt.value)
+		}
+
+		got, ok := serverPollInterval(res)
+
+		if got != tt.want || ok != tt.ok {
+			t.Fatalf("%s: %q: got %v, %v, want %v, %v", tt.header, tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestWaitForStatusHonoursServerInterval(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("X-Poll-Interval", "1")
+			w.Write([]byte(`{"state":"pending"}`))
+
+			return
+		}
+
+		w.Write([]byte(`{"state":"done"}`))
+	}))
+	defer srv.Close()
+
+	start := time.Now()
+	_, err := WaitForStatus(NewClient(srv.URL).NewRequest(GET, "/"), func(s jobState) bool {
+		return s.State == "done"
+	}, WaitOptions{InitialInterval: 10 * time.Millisecond})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("polled again after %v, want at least 1s", elapsed)
+	}
+}
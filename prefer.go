@@ -0,0 +1,16 @@
+package gors
+
+import "net/http"
+
+// SetPrefer sets the Prefer header (RFC 7240), e.g. "respond-async",
+// "return=minimal" or "wait=30", as used by OData and async APIs. Several
+// preferences are separated by commas.
+func (r *Request) SetPrefer(value string) {
+	r.SetHeader("Prefer", value)
+}
+
+// PreferenceApplied returns the Preference-Applied header, listing which of
+// the request's preferences the server honoured.
+func PreferenceApplied(res *http.Response) string {
+	return res.Header.Get("Preference-Applied")
+}
@@ -0,0 +1,31 @@
+package gors
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetPrefer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Preference-Applied", r.Header.Get("Prefer"))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	r := NewClient(srv.URL).NewRequest(POST, "/")
+	r.SetPrefer("respond-async, wait=30")
+
+	res, err := r.do(context.Background())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer res.Body.Close()
+
+	if got := PreferenceApplied(res); got != "respond-async, wait=30" {
+		t.Fatalf("got %q, want %q", got, "respond-async, wait=30")
+	}
+}
@@ -0,0 +1,81 @@
+package gors
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// SetQuerySort chooses whether query parameters are sent sorted by key (the
+// default, as url.Values.Encode does) or in the order they were set, which
+// some signature schemes require. Sorted, the parameters of the base URL
+// are sorted together with the request's; otherwise they come first,
+// unchanged.
+func (c *Client) SetQuerySort(sorted bool) {
+	c.unsortedQuery = !sorted
+}
+
+// encodeQuery merges the request's query parameters into the raw query of
+// the base URL.
+func (r *Request) encodeQuery(base string) (string, error) {
+	if !r.client.unsortedQuery {
+		q, err := url.ParseQuery(base)
+
+		if err != nil {
+			return "", fmt.Errorf("invalid base URL query %q: %w", base, err)
+		}
+
+		for k, v := range r.Query {
+			q.Add(k, v)
+		}
+
+		return q.Encode(), nil
+	}
+
+	var parts []string
+
+	if base != "" {
+		parts = append(parts, base)
+	}
+
+	seen := make(map[string]bool, len(r.Query))
+
+	for _, k := range r.queryOrder {
+		if v, ok := r.Query[k]; ok && !seen[k] {
+			parts = append(parts, url.QueryEscape(k)+"="+url.QueryEscape(v))
+			seen[k] = true
+		}
+	}
+
+	var rest []string
+
+	for k := range r.Query {
+		if !seen[k] {
+			rest = append(rest, k)
+		}
+	}
+
+	sort.Strings(rest)
+
+	for _, k := range rest {
+		parts = append(parts, url.QueryEscape(k)+"="+url.QueryEscape(r.Query[k]))
+	}
+
+	return strings.Join(parts, "&"), nil
+}
+
+// QueryString returns the query exactly as it will be sent, including
+// parameters from the base URL, e.g. to log or sign it. If the base URL is
+// invalid, only the request's parameters are returned.
+func (r *Request) QueryString() string {
+	if apiURL, err := r.resolveURL(); err == nil {
+		if query, err := r.encodeQuery(apiURL.RawQuery); err == nil {
+			return query
+		}
+	}
+
+	query, _ := r.encodeQuery("")
+
+	return query
+}
@@ -0,0 +1,106 @@
+package gors
+
+import (
+	"testing"
+)
+
+func TestMergeQuery(t *testing.T) {
+	r := NewClient("http://example.com").NewRequest(GET, "/")
+	r.MergeQuery(
+		map[string]string{"limit": "10", "sort": "name", "format": "json"},
+		map[string]string{"limit": "20", "sort": "date"},
+		map[string]string{"limit": "30"},
+	)
+
+	want := map[string]string{"limit": "30", "sort": "date", "format": "json"}
+
+	if len(r.Query) != len(want) {
+		t.Fatalf("got %v, want %v", r.Query, want)
+	}
+
+	for k, v := range want {
+		if r.Query[k] != v {
+			t.Errorf("got %s=%q, want %q", k, r.Query[k], v)
+		}
+	}
+}
+
+func TestSetQuerySort(t *testing.T) {
+	build := func(c Client) string {
+		r := c.NewRequest(GET, "/p")
+		r.SetQuery("b", 1)
+		r.SetQuery("a", "x y")
+		r.SetQuery("c", 3)
+
+		u, err := r.BuildURL()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return u.RawQuery
+	}
+
+	c := NewClient("http://example.com/?z=1&y=2")
+
+	if got, want := build(c), "a=x+y&b=1&c=3&y=2&z=1"; got != want {
+		t.Errorf("sorted: got %s, want %s", got, want)
+	}
+
+	c.SetQuerySort(false)
+
+	if got, want := build(c), "z=1&y=2&b=1&a=x+y&c=3"; got != want {
+		t.Errorf("unsorted: got %s, want %s", got, want)
+	}
+}
+
+func TestInvalidBaseQuery(t *testing.T) {
+	r := NewClient("http://example.com/?a=%zz").NewRequest(GET, "/")
+
+	if _, err := r.BuildURL(); err == nil {
+		t.Fatal("BuildURL accepted an invalid base URL query")
+	}
+}
+
+func TestQueryString(t *testing.T) {
+	newRequest := func(c Client) *Request {
+		r := c.NewRequest(GET, "/")
+		r.SetQuery("z", "a b")
+		r.SetQuery("a", 2)
+
+		return r
+	}
+
+	c := NewClient("http://example.com/api?k=1")
+
+	for _, sorted := range []bool{true, false} {
+		c.SetQuerySort(sorted)
+
+		r := newRequest(c)
+		u, err := r.BuildURL()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if r.QueryString() != u.RawQuery {
+			t.Errorf("sorted %v: got %s, but %s is sent", sorted, r.QueryString(), u.RawQuery)
+		}
+	}
+
+	c.SetQuerySort(true)
+
+	if got := newRequest(c).QueryString(); got != "a=2&k=1&z=a+b" {
+		t.Errorf("sorted: got %s", got)
+	}
+
+	c.SetQuerySort(false)
+
+	if got := newRequest(c).QueryString(); got != "k=1&z=a+b&a=2" {
+		t.Errorf("unsorted: got %s", got)
+	}
+
+	if got := newRequest(NewClient("http://example.com/?k=%zz")).QueryString(); got != "a=2&z=a+b" {
+		t.Errorf("invalid base URL: got %s, want the request's parameters only", got)
+	}
+}
@@ -0,0 +1,154 @@
+package gors
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SetQueryStruct sets query parameters from the fields of a struct. The
+// `query` tag names the parameter and takes these options:
+//
+//	omitempty  skip zero values
+//	dotted     join nested keys as parent.child
+//	brackets   join nested keys as parent[child]
+//
+// Nested structs default to dotted keys (filter.status=active) and maps to
+// brackets (labels[env]=prod). Slices are sent comma-separated, nil
+// pointers are skipped and a tag of "-" ignores the field.
+func (r *Request) SetQueryStruct(v interface{}) error {
+	rv := reflect.ValueOf(v)
+
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return errors.New("query struct is nil")
+		}
+
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("query struct must be a struct, got %s", rv.Kind())
+	}
+
+	return r.setQueryFields("", "", rv)
+}
+
+type queryTag struct {
+	name      string
+	omitEmpty bool
+	style     string
+}
+
+func parseQueryTag(f reflect.StructField) queryTag {
+	tag := queryTag{name: f.Name}
+	parts := strings.Split(f.Tag.Get("query"), ",")
+
+	if parts[0] != "" {
+		tag.name = parts[0]
+	}
+
+	for _, opt := range parts[1:] {
+		switch opt {
+		case "omitempty":
+			tag.omitEmpty = true
+		case "dotted", "brackets":
+			tag.style = opt
+		}
+	}
+
+	return tag
+}
+
+func nestKey(prefix, style, name string) string {
+	switch {
+	case prefix == "":
+		return name
+	case style == "brackets":
+		return prefix + "[" + name + "]"
+	default:
+		return prefix + "." + name
+	}
+}
+
+func (r *Request) setQueryFields(prefix, style string, rv reflect.Value) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+
+		if !f.IsExported() || f.Tag.Get("query") == "-" {
+			continue
+		}
+
+		tag := parseQueryTag(f)
+		fv := rv.Field(i)
+
+		if tag.omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		if err := r.setQueryValue(nestKey(prefix, style, tag.name), tag.style, fv); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Request) setQueryValue(key, style string, v reflect.Value) error {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	if t, ok := v.Interface().(time.Time); ok {
+		r.SetQuery(key, t.Format(time.RFC3339))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if style == "" {
+			style = "dotted"
+		}
+
+		return r.setQueryFields(key, style, v)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("query map %s must have string keys", key)
+		}
+
+		if style == "" {
+			style = "brackets"
+		}
+
+		iter := v.MapRange()
+
+		for iter.Next() {
+			if err := r.setQueryValue(nestKey(key, style, iter.Key().String()), style, iter.Value()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case reflect.Slice, reflect.Array:
+		items := make([]string, v.Len())
+
+		for i := range items {
+			items[i] = fmt.Sprintf("%v", v.Index(i).Interface())
+		}
+
+		r.SetQuery(key, strings.Join(items, ","))
+
+		return nil
+	default:
+		r.SetQuery(key, v.Interface())
+		return nil
+	}
+}
@@ -0,0 +1,50 @@
+package gors
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSetQueryStructNested(t *testing.T) {
+	type filter struct {
+		Status string `query:"status"`
+		Range  struct {
+			Min int `query:"min"`
+		} `query:"range,brackets"`
+	}
+
+	type params struct {
+		Filter filter            `query:"filter"`
+		Labels map[string]string `query:"labels"`
+		Meta   map[string]int    `query:"meta,dotted"`
+		Tags   []string          `query:"tags"`
+		Skip   string            `query:"skip,omitempty"`
+		Page   *int              `query:"page"`
+	}
+
+	p := params{
+		Filter: filter{Status: "active"},
+		Labels: map[string]string{"env": "prod"},
+		Meta:   map[string]int{"rev": 2},
+		Tags:   []string{"a", "b"},
+	}
+	p.Filter.Range.Min = 3
+
+	r := NewClient("http://example.com").NewRequest(GET, "/")
+
+	if err := r.SetQueryStruct(&p); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"filter.status":     "active",
+		"filter.range[min]": "3",
+		"labels[env]":       "prod",
+		"meta.rev":          "2",
+		"tags":              "a,b",
+	}
+
+	if fmt.Sprint(r.GetQuery()) != fmt.Sprint(want) {
+		t.Fatalf("got %v, want %v", r.GetQuery(), want)
+	}
+}
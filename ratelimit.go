@@ -0,0 +1,129 @@
+package gors
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimitBucket names the bucket of requests without one.
+const DefaultRateLimitBucket = "default"
+
+// RateLimitConfig configures a token bucket: Rate requests per second on
+// average, with bursts of up to Burst requests.
+type RateLimitConfig struct {
+	Rate  float64
+	Burst int
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// SetRateLimitBuckets throttles requests per named bucket, each bucket
+// independently of the others. Requests pick a bucket with
+// SetRateLimitBucket; those without one, or with a name not configured
+// here, use DefaultRateLimitBucket, and are not throttled if it isn't
+// configured either. Every attempt, including retries, takes a token. Like
+// the headers of AddDefaultHeader, the buckets are shared by all copies of
+// a client created with NewClient, so copies draw on the same quotas, and
+// calling it on any copy replaces the buckets of all of them.
+func (c *Client) SetRateLimitBuckets(buckets map[string]RateLimitConfig) {
+	if c.shared == nil {
+		c.shared = &sharedState{}
+	}
+
+	limiter := make(map[string]*tokenBucket, len(buckets))
+
+	for name, cfg := range buckets {
+		if cfg.Rate <= 0 {
+			continue
+		}
+
+		burst := float64(cfg.Burst)
+
+		if burst < 1 {
+			burst = 1
+		}
+
+		limiter[name] = &tokenBucket{rate: cfg.Rate, burst: burst, tokens: burst, last: time.Now()}
+	}
+
+	c.shared.limiter = limiter
+}
+
+// SetRateLimitBucket sets the bucket of the client's rate limiter the
+// request is counted against.
+func (r *Request) SetRateLimitBucket(name string) {
+	r.rateLimitBucket = name
+}
+
+// waitRateLimit blocks until the request's bucket has a token to spare, or
+// ctx is done.
+func (r *Request) waitRateLimit(ctx context.Context) error {
+	if r.client.shared == nil || r.client.shared.limiter == nil {
+		return nil
+	}
+
+	b, ok := r.client.shared.limiter[r.rateLimitBucket]
+
+	if !ok {
+		b, ok = r.client.shared.limiter[DefaultRateLimitBucket]
+	}
+
+	if !ok {
+		return nil
+	}
+
+	wait := b.reserve()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return classifyTimeout(ctx.Err())
+	}
+}
+
+// reserve takes a token, possibly one yet to come, and returns how long to
+// wait until it is available.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	b.last = now
+
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel gives back a token reserved by a request that won't be sent.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+}
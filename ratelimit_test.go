@@ -0,0 +1,57 @@
+package gors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSetRateLimitBuckets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetRateLimitBuckets(map[string]RateLimitConfig{
+		"a": {Rate: 10, Burst: 1},
+		"b": {Rate: 10, Burst: 1},
+	})
+
+	send := func(client Client, bucket string) {
+		r := client.NewRequest(GET, "/")
+
+		if bucket != "" {
+			r.SetRateLimitBucket(bucket)
+		}
+
+		if _, err := r.Send(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start := time.Now()
+	send(c, "a")
+	send(c, "b")
+
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("bucket b waited on bucket a: %v", elapsed)
+	}
+
+	// A copy of the client draws on the same quota.
+	copied := c
+	send(copied, "a")
+	send(copied, "a")
+
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("bucket a not throttled: %v", elapsed)
+	}
+
+	// Without a default bucket, requests without one aren't throttled.
+	start = time.Now()
+	send(c, "")
+	send(c, "")
+
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("request without a bucket throttled: %v", elapsed)
+	}
+}
@@ -0,0 +1,118 @@
+package gors
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+var ErrMalformedRedirect = errors.New("redirect response without Location header")
+
+// StrictRedirects makes requests fail with ErrMalformedRedirect when a
+// redirect status arrives without a Location header. 300 and 304, for
+// which Location is optional or meaningless, are exempt. By default such
+// responses are returned as-is, which is what net/http does.
+func (c *Client) StrictRedirects(strict bool) {
+	c.strictRedirects = strict
+}
+
+func isMalformedRedirect(res *http.Response) bool {
+	return res.StatusCode >= 300 && res.StatusCode < 400 &&
+		res.StatusCode != http.StatusMultipleChoices && res.StatusCode != http.StatusNotModified &&
+		res.Header.Get("Location") == ""
+}
+
+// FinalURL returns the absolute URL that produced res, i.e. the last hop
+// after any redirects. net/http resolves relative Location headers against
+// the previous request URL; gors' redirect options only decide whether a
+// hop is followed and never rewrite the target, so that resolution holds.
+func FinalURL(res *http.Response) *url.URL {
+	if res.Request == nil {
+		return nil
+	}
+
+	return res.Request.URL
+}
+
+// SetMaxRedirects limits how many redirects requests follow. When the limit
+// is reached the redirect response itself is returned. Without a limit
+// net/http's default of 10 applies.
+func (c *Client) SetMaxRedirects(n int) {
+	c.maxRedirects = &n
+}
+
+// SetMaxRedirects overrides the client's redirect limit for this request.
+func (r *Request) SetMaxRedirects(n int) {
+	r.maxRedirects = &n
+}
+
+// PreserveMethodOnRedirect makes requests keep their method and body on
+// every redirect. By default the standard rules apply: 307 and 308 repeat
+// the method and body, while 303, and 301 or 302 after a POST, switch to a
+// GET without body. Some non-standard servers expect the method to be kept
+// on those as well.
+func (c *Client) PreserveMethodOnRedirect(preserve bool) {
+	c.preserveMethod = preserve
+}
+
+// maxDefaultRedirects is net/http's limit, which applies when no limit is
+// set.
+const maxDefaultRedirects = 10
+
+func (r *Request) checkRedirect() func(*http.Request, []*http.Request) error {
+	max := r.client.maxRedirects
+
+	if r.maxRedirects != nil {
+		max = r.maxRedirects
+	}
+
+	if max == nil && !r.client.preserveMethod {
+		return nil
+	}
+
+	return func(req *http.Request, via []*http.Request) error {
+		if max != nil && len(via) > *max {
+			return http.ErrUseLastResponse
+		}
+
+		if max == nil && len(via) >= maxDefaultRedirects {
+			return errors.New("stopped after 10 redirects")
+		}
+
+		if r.client.preserveMethod {
+			return restoreMethod(req, via[0])
+		}
+
+		return nil
+	}
+}
+
+// restoreMethod gives a redirected request the method and body of the
+// original request, if net/http switched it to GET.
+func restoreMethod(req, orig *http.Request) error {
+	if req.Method == orig.Method {
+		return nil
+	}
+
+	req.Method = orig.Method
+
+	if orig.GetBody == nil {
+		return nil
+	}
+
+	body, err := orig.GetBody()
+
+	if err != nil {
+		return err
+	}
+
+	req.Body = body
+	req.GetBody = orig.GetBody
+	req.ContentLength = orig.ContentLength
+
+	if ct := orig.Header.Get("Content-Type"); ct != "" {
+		req.Header.Set("Content-Type", ct)
+	}
+
+	return nil
+}
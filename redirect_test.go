@@ -0,0 +1,196 @@
+package gors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestStrictRedirects(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusFound)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	code, err := c.NewRequest(GET, "/").SendStatus()
+
+	if err != nil || code != http.StatusFound {
+		t.Fatalf("lenient: got %d, %v, want the 302 passed through", code, err)
+	}
+
+	c.StrictRedirects(true)
+
+	if _, err := c.NewRequest(GET, "/").SendStatus(); !errors.Is(err, ErrMalformedRedirect) {
+		t.Fatalf("strict: got %v, want ErrMalformedRedirect", err)
+	}
+}
+
+func TestStrictRedirectsAllowOptionalLocation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		code, _ := strconv.Atoi(r.URL.Query().Get("code"))
+		w.WriteHeader(code)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.StrictRedirects(true)
+
+	for _, want := range []int{http.StatusMultipleChoices, http.StatusNotModified} {
+		r := c.NewRequest(GET, "/")
+		r.SetQuery("code", strconv.Itoa(want))
+
+		code, err := r.SendStatus()
+
+		if err != nil || code != want {
+			t.Fatalf("got %d, %v, want %d without an error", code, err, want)
+		}
+	}
+}
+
+func TestFinalURLAfterRelativeRedirect(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/old":
+			w.Header().Set("Location", "../v2/new?x=1")
+			w.WriteHeader(http.StatusFound)
+		case "/api/v2/new":
+			w.Write([]byte("ok"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	// A redirect limit and PreserveMethodOnRedirect both install a custom
+	// CheckRedirect, which must not break the resolution.
+	c := NewClient(srv.URL)
+	c.SetMaxRedirects(5)
+	c.PreserveMethodOnRedirect(true)
+
+	res := sendForResponse(t, c.NewRequest(GET, "/api/v1/old"))
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d", res.StatusCode)
+	}
+
+	if got, want := FinalURL(res).String(), srv.URL+"/api/v2/new?x=1"; got != want {
+		t.Fatalf("got final URL %s, want %s", got, want)
+	}
+}
+
+func TestRequestMaxRedirects(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/a":
+			http.Redirect(w, r, "/b", http.StatusFound)
+		case "/b":
+			http.Redirect(w, r, "/c", http.StatusFound)
+		default:
+			w.Write([]byte("done"))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetMaxRedirects(5)
+
+	res, err := c.NewRequest(GET, "/a").Send()
+
+	if err != nil || res.Code != http.StatusOK || string(res.Body) != "done" {
+		t.Fatalf("client limit: got %d %q, %v", res.Code, res.Body, err)
+	}
+
+	r := c.NewRequest(GET, "/a")
+	r.SetMaxRedirects(0)
+
+	res, err = r.Send()
+
+	if err != nil || res.Code != http.StatusFound {
+		t.Fatalf("request limit: got %d, %v, want the first 302", res.Code, err)
+	}
+
+	r = c.NewRequest(GET, "/a")
+	r.SetMaxRedirects(1)
+
+	if res := sendForResponse(t, r); res.StatusCode != http.StatusFound || FinalURL(res).Path != "/b" {
+		t.Fatalf("limit 1: got %d from %s, want the 302 from /b", res.StatusCode, FinalURL(res))
+	}
+}
+
+func TestRedirectMethod(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/307", "/308", "/303":
+			code, _ := strconv.Atoi(r.URL.Path[1:])
+			http.Redirect(w, r, "/echo", code)
+		default:
+			body, _ := io.ReadAll(r.Body)
+			fmt.Fprintf(w, "%s %s %s", r.Method, body, r.Header.Get("Content-Type"))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	send := func(path string) string {
+		r := c.NewRequest(POST, path)
+
+		if err := r.SetJSONBody(map[string]int{"a": 1}); err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := r.Send()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return string(res.Body)
+	}
+
+	for _, path := range []string{"/307", "/308"} {
+		if got := send(path); got != `POST {"a":1} application/json` {
+			t.Errorf("%s: got %q, want the POST replayed", path, got)
+		}
+	}
+
+	if got := send("/303"); got != "GET  " {
+		t.Errorf("303: got %q, want a GET without body", got)
+	}
+
+	c.PreserveMethodOnRedirect(true)
+
+	if got := send("/303"); got != `POST {"a":1} application/json` {
+		t.Errorf("303 with PreserveMethodOnRedirect: got %q, want the POST replayed", got)
+	}
+}
+
+func TestByteBodyReplayedOnRedirect(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = append(got, r.URL.Path+":"+string(body))
+
+		if r.URL.Path == "/a" {
+			http.Redirect(w, r, "/b", http.StatusTemporaryRedirect)
+		}
+	}))
+	defer srv.Close()
+
+	r := NewClient(srv.URL).NewRequest(POST, "/a")
+	r.SetBody([]byte("hello world"))
+
+	if _, err := r.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 2 || got[0] != "/a:hello world" || got[1] != "/b:hello world" {
+		t.Fatalf("got %q, want the full body sent to /a and /b", got)
+	}
+}
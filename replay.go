@@ -0,0 +1,61 @@
+package gors
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ReplayDump builds a request from a dump as produced by Dump or
+// httputil.DumpRequestOut, to replay captured traffic. The dump's Host
+// header and the client's base URL scheme give the target. Headers net/http
+// adds by itself, such as Content-Length, its default User-Agent and
+// Accept-Encoding: gzip, are left to it.
+func ReplayDump(c Client, dump string) (*Request, error) {
+	req, err := http.ReadRequest(bufio.NewReader(strings.NewReader(dump)))
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(req.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r := c.NewRequest(req.Method, req.URL.Path)
+
+	if req.Host != "" {
+		scheme := "https"
+
+		if base, err := url.Parse(c.BaseURL); err == nil && base.Scheme != "" {
+			scheme = base.Scheme
+		}
+
+		r.baseURL = scheme + "://" + req.Host
+	}
+
+	for k, values := range req.URL.Query() {
+		r.SetQuery(k, values[len(values)-1])
+	}
+
+	for k, values := range req.Header {
+		switch {
+		case k == "Content-Length",
+			k == "User-Agent" && values[0] == defaultUserAgent,
+			k == "Accept-Encoding" && values[0] == "gzip":
+			continue
+		}
+
+		r.SetHeader(k, strings.Join(values, ", "))
+	}
+
+	if len(body) > 0 {
+		r.SetBody(body)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,61 @@
+package gors
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReplayDump(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = r.Method + " " + r.URL.String() + " " + r.Header.Get("X-A") + " " + string(body)
+	}))
+	defer srv.Close()
+
+	r := NewClient(srv.URL+"/api").NewRequest(POST, "/items")
+	r.SetQuery("q", "a b")
+	r.SetHeader("X-A", "1")
+
+	if err := r.SetJSONBody(map[string]int{"a": 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	dump, err := r.Dump()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayed, err := ReplayDump(NewClient(srv.URL), dump)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	redump, err := replayed.Dump()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if redump != dump {
+		t.Fatalf("got dump\n%q\nwant\n%q", redump, dump)
+	}
+
+	if _, err := replayed.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `POST /api/items?q=a+b 1 {"a":1}`; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestReplayDumpMalformed(t *testing.T) {
+	if _, err := ReplayDump(NewClient("http://example.com"), "not a request"); err == nil {
+		t.Fatal("expected an error")
+	}
+}
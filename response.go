@@ -0,0 +1,154 @@
+package gors
+
+import (
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func Cookies(res *http.Response) []*http.Cookie {
+	return res.Cookies()
+}
+
+// CookieValue returns the value of the named cookie set by the response.
+func CookieValue(res *http.Response, name string) (string, bool) {
+	for _, c := range res.Cookies() {
+		if c.Name == name {
+			return c.Value, true
+		}
+	}
+
+	return "", false
+}
+
+func ContentType(res *http.Response) string {
+	return res.Header.Get("Content-Type")
+}
+
+// ContentLength returns the Content-Length header, or -1 when it is missing
+// or invalid.
+func ContentLength(res *http.Response) int64 {
+	n, err := strconv.ParseInt(strings.TrimSpace(res.Header.Get("Content-Length")), 10, 64)
+
+	if err != nil || n < 0 {
+		return -1
+	}
+
+	return n
+}
+
+// RetryAfter parses the Retry-After header, which is either a number of
+// seconds or an HTTP date. A date in the past gives a zero duration, and a
+// number of seconds too large for a time.Duration the longest one.
+func RetryAfter(res *http.Response) (time.Duration, bool) {
+	v := strings.TrimSpace(res.Header.Get("Retry-After"))
+
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+
+		if int64(secs) > math.MaxInt64/int64(time.Second) {
+			return math.MaxInt64, true
+		}
+
+		return time.Duration(secs) * time.Second, true
+	}
+
+	t, err := http.ParseTime(v)
+
+	if err != nil {
+		return 0, false
+	}
+
+	if d := time.Until(t); d > 0 {
+		return d, true
+	}
+
+	return 0, true
+}
+
+func LastModified(res *http.Response) (time.Time, bool) {
+	t, err := http.ParseTime(res.Header.Get("Last-Modified"))
+
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+// ExpectContentType checks that the response media type is want, ignoring
+// parameters such as charset, so "application/json; charset=utf-8"
+// matches "application/json".
+func ExpectContentType(res *http.Response, want string) error {
+	got := ContentType(res)
+	mediaType, _, err := mime.ParseMediaType(got)
+
+	if err != nil || !strings.EqualFold(mediaType, want) {
+		return fmt.Errorf("unexpected content type %q, want %q", got, want)
+	}
+
+	return nil
+}
+
+// CreatedLocation returns the URL of the resource a 201 Created response
+// points to in its Location header, resolved against the request URL.
+func CreatedLocation(res *http.Response) (string, bool) {
+	if res.StatusCode != http.StatusCreated {
+		return "", false
+	}
+
+	u, err := res.Location()
+
+	if err != nil {
+		return "", false
+	}
+
+	return u.String(), true
+}
+
+// Warnings returns the warnings in the response's Warning headers, such as
+// `299 - "Deprecated API"`, one per entry. Commas inside the quoted warning
+// text don't split an entry.
+func Warnings(res *http.Response) []string {
+	var warnings []string
+
+	for _, v := range res.Header.Values("Warning") {
+		inQuotes, escaped, start := false, false, 0
+
+		for i := 0; i <= len(v); i++ {
+			if i < len(v) {
+				switch c := v[i]; {
+				case escaped:
+					escaped = false
+					continue
+				case c == '\\' && inQuotes:
+					escaped = true
+					continue
+				case c == '"':
+					inQuotes = !inQuotes
+					continue
+				case c != ',' || inQuotes:
+					continue
+				}
+			}
+
+			if w := strings.TrimSpace(v[start:i]); w != "" {
+				warnings = append(warnings, w)
+			}
+
+			start = i + 1
+		}
+	}
+
+	return warnings
+}
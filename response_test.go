@@ -0,0 +1,182 @@
+package gors
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sendForResponse sends r and returns the response with its body read.
+func sendForResponse(t *testing.T, r *Request) *http.Response {
+	t.Helper()
+
+	res, err := r.Stream(func(body io.Reader) error {
+		_, err := io.Copy(io.Discard, body)
+		return err
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res
+}
+
+func TestCookies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+		http.SetCookie(w, &http.Cookie{Name: "theme", Value: "dark"})
+	}))
+	defer srv.Close()
+
+	res := sendForResponse(t, NewClient(srv.URL).NewRequest(GET, "/"))
+
+	if cookies := Cookies(res); len(cookies) != 2 {
+		t.Fatalf("got %d cookies, want 2", len(cookies))
+	}
+
+	if v, ok := CookieValue(res, "theme"); !ok || v != "dark" {
+		t.Errorf("got theme %q, %v, want dark", v, ok)
+	}
+
+	if _, ok := CookieValue(res, "missing"); ok {
+		t.Error("found a cookie that wasn't set")
+	}
+}
+
+func responseWithHeader(key, value string) *http.Response {
+	res := &http.Response{Header: make(http.Header)}
+
+	if value != "" {
+		res.Header.Set(key, value)
+	}
+
+	return res
+}
+
+func TestContentType(t *testing.T) {
+	if got := ContentType(responseWithHeader("Content-Type", "application/json; charset=utf-8")); got != "application/json; charset=utf-8" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestContentLength(t *testing.T) {
+	tests := map[string]int64{"42": 42, " 7 ": 7, "": -1, "abc": -1, "-3": -1}
+
+	for header, want := range tests {
+		if got := ContentLength(responseWithHeader("Content-Length", header)); got != want {
+			t.Errorf("ContentLength(%q) = %d, want %d", header, got, want)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if d, ok := RetryAfter(responseWithHeader("Retry-After", "120")); !ok || d != 2*time.Minute {
+		t.Errorf("seconds: got %v, %v", d, ok)
+	}
+
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+
+	if d, ok := RetryAfter(responseWithHeader("Retry-After", future)); !ok || d < 59*time.Minute || d > time.Hour {
+		t.Errorf("future date: got %v, %v", d, ok)
+	}
+
+	past := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+
+	if d, ok := RetryAfter(responseWithHeader("Retry-After", past)); !ok || d != 0 {
+		t.Errorf("past date: got %v, %v", d, ok)
+	}
+
+	if d, ok := RetryAfter(responseWithHeader("Retry-After", "99999999999999")); !ok || d != math.MaxInt64 {
+		t.Errorf("huge seconds: got %v, %v, want the longest duration", d, ok)
+	}
+
+	for _, header := range []string{"", "soon", "-5"} {
+		if _, ok := RetryAfter(responseWithHeader("Retry-After", header)); ok {
+			t.Errorf("RetryAfter(%q) reported a value", header)
+		}
+	}
+}
+
+func TestLastModified(t *testing.T) {
+	got, ok := LastModified(responseWithHeader("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT"))
+
+	if want := time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC); !ok || !got.Equal(want) {
+		t.Errorf("got %v, %v, want %v", got, ok, want)
+	}
+
+	if _, ok := LastModified(responseWithHeader("Last-Modified", "yesterday")); ok {
+		t.Error("parsed an invalid date")
+	}
+}
+
+func TestExpectContentType(t *testing.T) {
+	res := responseWithHeader("Content-Type", "application/json; charset=utf-8")
+
+	if err := ExpectContentType(res, "application/json"); err != nil {
+		t.Fatal(err)
+	}
+
+	err := ExpectContentType(res, "text/html")
+
+	if err == nil || !strings.Contains(err.Error(), "application/json; charset=utf-8") {
+		t.Fatalf("got %v, want an error naming the actual type", err)
+	}
+}
+
+func TestCreatedLocation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "items/42")
+
+		if r.Method == POST {
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	res := sendForResponse(t, c.NewRequest(POST, "/api/"))
+
+	location, ok := CreatedLocation(res)
+
+	if !ok || location != srv.URL+"/api/items/42" {
+		t.Fatalf("got %q, %v, want %s/api/items/42", location, ok, srv.URL)
+	}
+
+	if _, ok := CreatedLocation(sendForResponse(t, c.NewRequest(GET, "/api/"))); ok {
+		t.Fatal("got a location for a response other than 201")
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	res := &http.Response{Header: http.Header{}}
+	res.Header.Add("Warning", `299 - "Deprecated, use v2", 110 anderson/1.3.37 "Response is stale"`)
+	res.Header.Add("Warning", `199 - "say \"hi\", ok" "Wed, 21 Oct 2015 07:28:00 GMT"`)
+
+	want := []string{
+		`299 - "Deprecated, use v2"`,
+		`110 anderson/1.3.37 "Response is stale"`,
+		`199 - "say \"hi\", ok" "Wed, 21 Oct 2015 07:28:00 GMT"`,
+	}
+
+	got := Warnings(res)
+
+	if len(got) != len(want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("warning %d: got %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if got := Warnings(&http.Response{Header: http.Header{}}); len(got) != 0 {
+		t.Fatalf("got %q, want none", got)
+	}
+}
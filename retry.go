@@ -0,0 +1,200 @@
+package gors
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy decides when the buffered helpers, such as Send and
+// SendWithJSONResponse, send a request again.
+//
+// A response is retried when its status is in Statuses, or when Statuses
+// is empty and IsRetryable would accept it (5xx, 408 and 429). Transport
+// errors are retried for idempotent methods, or requests with an
+// idempotency key, when IsRetryable accepts them. Requests with a streamed
+// body are only retried when it was set with SetBodyReaderWithGetBody.
+type RetryPolicy struct {
+	MaxRetries int
+	Statuses   []int
+
+	// Backoff is the delay before the first retry; it doubles with every
+	// further retry. A Retry-After header on the response takes precedence.
+	Backoff time.Duration
+
+	// MaxBackoff caps the doubled Backoff, where zero means one minute,
+	// and when set also the delays servers ask for with Retry-After.
+	MaxBackoff time.Duration
+}
+
+const defaultMaxBackoff = time.Minute
+
+func (c *Client) SetRetryPolicy(p RetryPolicy) {
+	c.retry = p
+}
+
+// RetryOnHeader retries responses carrying the header name with the given
+// value, compared case-insensitively, whatever their status. This suits
+// APIs that flag transient failures with a header rather than a status.
+// The number of retries and the backoff come from the RetryPolicy.
+func (c *Client) RetryOnHeader(name, value string) {
+	c.retryHeaders = append(c.retryHeaders, [2]string{name, value})
+}
+
+// SetRetryDecider replaces the client's rules for deciding whether this
+// request is retried; res is nil when err is a transport error. The number
+// of retries and the backoff still come from the client's RetryPolicy.
+func (r *Request) SetRetryDecider(fn func(res *http.Response, err error) bool) {
+	r.retryDecider = fn
+}
+
+// NoRetry makes the request ignore the client's retry settings and be
+// attempted exactly once, e.g. for a non-idempotent operation.
+func (r *Request) NoRetry() {
+	r.noRetry = true
+}
+
+func (r *Request) shouldRetry(attempt int, res *http.Response, err error) bool {
+	if r.noRetry || attempt > r.client.retry.MaxRetries || (r.bodyStream != nil && !r.bodyReplayable) {
+		return false
+	}
+
+	if r.retryDecider != nil {
+		return r.retryDecider(res, err)
+	}
+
+	if err != nil {
+		return (isIdempotent(r.Method) || r.hasIdempotencyKey()) && IsRetryable(err)
+	}
+
+	for _, h := range r.client.retryHeaders {
+		if strings.EqualFold(res.Header.Get(h[0]), h[1]) {
+			return true
+		}
+	}
+
+	if len(r.client.retry.Statuses) == 0 {
+		return isRetryableStatus(res.StatusCode)
+	}
+
+	for _, code := range r.client.retry.Statuses {
+		if res.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// waitRetry sleeps before the retry following attempt, or returns early with
+// the context's error.
+func (r *Request) waitRetry(ctx context.Context, attempt int, res *http.Response) error {
+	delay := r.client.retry.backoff(attempt)
+
+	if res != nil {
+		if after, ok := RetryAfter(res); ok {
+			delay = after
+
+			if limit := r.client.retry.MaxBackoff; limit > 0 && delay > limit {
+				delay = limit
+			}
+		}
+	}
+
+	if delay <= 0 {
+		return classifyTimeout(ctx.Err())
+	}
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return classifyTimeout(ctx.Err())
+	}
+}
+
+// backoff returns the delay before the retry following attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	limit := p.MaxBackoff
+
+	if limit <= 0 {
+		limit = defaultMaxBackoff
+	}
+
+	delay := p.Backoff
+
+	for i := 1; i < attempt && delay > 0 && delay < limit; i++ {
+		delay *= 2
+	}
+
+	if delay > limit {
+		delay = limit
+	}
+
+	return delay
+}
+
+// RetryOnBodyReset retries idempotent requests up to retries more times when
+// the connection drops while the response body is being read, which happens
+// with stale keep-alive connections. It applies to the helpers that buffer
+// the whole body; streaming helpers have already handed data to the caller
+// and are never retried.
+func (c *Client) RetryOnBodyReset(retries int) {
+	c.bodyResetRetries = retries
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case GET, HEAD, PUT, DELETE, OPTIONS, TRACE:
+		return true
+	}
+
+	return false
+}
+
+func isConnectionReset(err error) bool {
+	return errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		strings.Contains(err.Error(), "GOAWAY")
+}
+
+// IsRetryable reports whether err is worth retrying: timeouts, connection
+// failures and *StatusError with a 5xx, 408 or 429 status. Other 4xx
+// statuses, cancellation and decoding errors are not retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var statusErr *StatusError
+
+	if errors.As(err, &statusErr) {
+		return isRetryableStatus(statusErr.Code)
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || isConnectionReset(err) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netErr net.Error
+
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	var opErr *net.OpError
+
+	return errors.As(err, &opErr)
+}
+
+func isRetryableStatus(code int) bool {
+	return code >= 500 || code == http.StatusRequestTimeout || code == http.StatusTooManyRequests
+}
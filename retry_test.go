@@ -0,0 +1,352 @@
+package gors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryOnBodyReset(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			// Promise more than is sent, then drop the connection.
+			w.Header().Set("Content-Length", "100")
+			w.Write([]byte(`{"a":`))
+			w.(http.Flusher).Flush()
+
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+
+			return
+		}
+
+		w.Write([]byte(`{"a":1}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.RetryOnBodyReset(1)
+
+	v, err := SendWithJSONResponse[map[string]int](c.NewRequest(GET, "/"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v["a"] != 1 {
+		t.Errorf("got %v", v)
+	}
+
+	if n := atomic.LoadInt32(&attempts); n != 2 {
+		t.Errorf("server saw %d attempts, want 2", n)
+	}
+}
+
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string   { return "i/o timeout" }
+func (timeoutErr) Timeout() bool   { return true }
+func (timeoutErr) Temporary() bool { return true }
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"503", &StatusError{Code: 503}, true},
+		{"500", &StatusError{Code: 500}, true},
+		{"429", &StatusError{Code: 429}, true},
+		{"408", &StatusError{Code: 408}, true},
+		{"404", &StatusError{Code: 404}, false},
+		{"400", &StatusError{Code: 400}, false},
+		{"wrapped 502", fmt.Errorf("fetch: %w", &StatusError{Code: 502}), true},
+		{"deadline", context.DeadlineExceeded, true},
+		{"net timeout", &net.OpError{Op: "read", Err: timeoutErr{}}, true},
+		{"unexpected EOF", io.ErrUnexpectedEOF, true},
+		{"canceled", context.Canceled, false},
+		{"malformed redirect", ErrMalformedRedirect, false},
+		{"other", errors.New("decode failed"), false},
+	}
+
+	for _, tt := range tests {
+		if got := IsRetryable(tt.err); got != tt.want {
+			t.Errorf("%s: IsRetryable(%v) = %v, want %v", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestIsRetryableConnectionRefused(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ln.Close()
+
+	_, err = NewClient("http://"+ln.Addr().String()).NewRequest(GET, "/").Send()
+
+	if err == nil || !IsRetryable(err) {
+		t.Fatalf("got %v, want a retryable connection error", err)
+	}
+}
+
+func TestSetPerAttemptHook(t *testing.T) {
+	var nonces []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nonces = append(nonces, r.Header.Get("X-Nonce"))
+
+		if len(nonces) < 3 {
+			// Promise more than is sent, then drop the connection.
+			w.Header().Set("Content-Length", "100")
+			w.Write([]byte("partial"))
+			w.(http.Flusher).Flush()
+
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+		}
+	}))
+	defer srv.Close()
+
+	var attempts []int
+	c := NewClient(srv.URL)
+	c.RetryOnBodyReset(3)
+	c.SetPerAttemptHook(func(attempt int, r *http.Request) {
+		attempts = append(attempts, attempt)
+		r.Header.Set("X-Nonce", "n"+strconv.Itoa(attempt))
+	})
+
+	res, err := c.NewRequest(GET, "/").Send()
+
+	if err != nil || res.Code != http.StatusOK {
+		t.Fatalf("got %d, %v", res.Code, err)
+	}
+
+	if got := fmt.Sprint(attempts); got != "[1 2 3]" {
+		t.Errorf("hook saw attempts %s, want [1 2 3]", got)
+	}
+
+	if got := fmt.Sprint(nonces); got != "[n1 n2 n3]" {
+		t.Errorf("server saw nonces %s, want [n1 n2 n3]", got)
+	}
+}
+
+func TestSetRetryDecider(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		if calls < 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"state":"pending"}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetRetryPolicy(RetryPolicy{MaxRetries: 3})
+
+	res, err := c.NewRequest(GET, "/").Send()
+
+	if err != nil || res.Code != http.StatusBadRequest || calls != 1 {
+		t.Fatalf("without a decider got %d, %v after %d calls, want the 400 unretried", res.Code, err, calls)
+	}
+
+	calls = 0
+	r := c.NewRequest(GET, "/")
+	r.SetRetryDecider(func(res *http.Response, err error) bool {
+		return res != nil && res.StatusCode == http.StatusBadRequest
+	})
+
+	res, err = r.Send()
+
+	if err != nil || res.Code != http.StatusOK {
+		t.Fatalf("got %d, %v", res.Code, err)
+	}
+
+	if calls != 3 {
+		t.Fatalf("server got %d calls, want 3", calls)
+	}
+}
+
+func TestRetryBackoffCap(t *testing.T) {
+	p := RetryPolicy{Backoff: time.Second}
+
+	for attempt, want := range map[int]time.Duration{
+		1:   time.Second,
+		3:   4 * time.Second,
+		200: time.Minute,
+	} {
+		if got := p.backoff(attempt); got != want {
+			t.Errorf("attempt %d: got %v, want %v", attempt, got, want)
+		}
+	}
+
+	p.MaxBackoff = 3 * time.Second
+
+	if got := p.backoff(3); got != 3*time.Second {
+		t.Errorf("got %v, want it capped at MaxBackoff", got)
+	}
+}
+
+func TestRetryAfterCappedByMaxBackoff(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "100000")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetRetryPolicy(RetryPolicy{MaxRetries: 1, MaxBackoff: 10 * time.Millisecond})
+
+	start := time.Now()
+	res, err := c.NewRequest(GET, "/").Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("got %d, want 200 after the retry", res.Code)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("waited %v, want Retry-After capped at MaxBackoff", elapsed)
+	}
+}
+
+func TestRetrySetBodyReaderWithGetBody(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+
+		if string(body) != "payload" {
+			t.Errorf("attempt %d got body %q, want payload", calls, body)
+		}
+
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetRetryPolicy(RetryPolicy{MaxRetries: 3})
+
+	var readers []io.Reader
+
+	r := c.NewRequest(PUT, "/")
+	r.SetBodyReaderWithGetBody(func() (io.ReadCloser, error) {
+		reader := strings.NewReader("payload")
+		readers = append(readers, reader)
+
+		return io.NopCloser(reader), nil
+	}, int64(len("payload")))
+
+	res, err := r.Send()
+
+	if err != nil || res.Code != http.StatusOK {
+		t.Fatalf("got %d, %v", res.Code, err)
+	}
+
+	if calls != 3 || len(readers) != 3 {
+		t.Fatalf("got %d attempts and %d readers, want 3 of each", calls, len(readers))
+	}
+
+	if readers[0] == readers[1] || readers[1] == readers[2] {
+		t.Fatal("an attempt reused the reader of the previous one")
+	}
+}
+
+func TestNoRetry(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetRetryPolicy(RetryPolicy{MaxRetries: 2})
+
+	r := c.NewRequest(POST, "/charge")
+	r.NoRetry()
+
+	res, err := r.Send()
+
+	if err != nil || res.Code != http.StatusServiceUnavailable {
+		t.Fatalf("got %d, %v", res.Code, err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("server got %d calls, want 1", calls)
+	}
+
+	calls = 0
+
+	if _, err := c.NewRequest(POST, "/charge").Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 3 {
+		t.Fatalf("without NoRetry the server got %d calls, want 3", calls)
+	}
+}
+
+func TestRetryOnHeader(t *testing.T) {
+	var calls, permanent int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/permanent" {
+			permanent++
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		calls++
+
+		if calls == 1 {
+			w.Header().Set("X-Transient", "TRUE")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetRetryPolicy(RetryPolicy{MaxRetries: 2})
+	c.RetryOnHeader("X-Transient", "true")
+
+	res, err := c.NewRequest(POST, "/").Send()
+
+	if err != nil || res.Code != http.StatusOK {
+		t.Fatalf("got %d, %v", res.Code, err)
+	}
+
+	if calls != 2 {
+		t.Fatalf("server got %d calls, want 2", calls)
+	}
+
+	res, err = c.NewRequest(POST, "/permanent").Send()
+
+	if err != nil || res.Code != http.StatusBadRequest || permanent != 1 {
+		t.Fatalf("got %d, %v after %d calls, want a 400 without the header unretried", res.Code, err, permanent)
+	}
+}
@@ -0,0 +1,232 @@
+package gors
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// SchemaError lists the ways a JSON document violates a schema.
+type SchemaError struct {
+	Violations []string
+}
+
+func (e *SchemaError) Error() string {
+	return "JSON schema violation: " + strings.Join(e.Violations, "; ")
+}
+
+// jsonSchema is a parsed JSON Schema. Only the commonly used keywords are
+// supported: type, enum, required, properties, additionalProperties, items,
+// minItems, maxItems, minLength, maxLength, pattern, minimum and maximum.
+type jsonSchema map[string]interface{}
+
+func parseJSONSchema(data []byte) (jsonSchema, error) {
+	var s jsonSchema
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+
+	return s, nil
+}
+
+// validate checks the JSON document data against the schema.
+func (s jsonSchema) validate(data []byte) error {
+	var doc interface{}
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return newJSONError(data, err)
+	}
+
+	var violations []string
+	s.check(doc, "$", &violations)
+
+	if len(violations) > 0 {
+		return &SchemaError{Violations: violations}
+	}
+
+	return nil
+}
+
+func (s jsonSchema) check(v interface{}, path string, violations *[]string) {
+	fail := func(format string, args ...interface{}) {
+		*violations = append(*violations, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	if t, ok := s["type"]; ok && !matchesType(v, t) {
+		fail("expected type %v, got %s", t, jsonType(v))
+		return
+	}
+
+	if enum, ok := s["enum"].([]interface{}); ok {
+		found := false
+
+		for _, e := range enum {
+			if reflect.DeepEqual(e, v) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			fail("value not in enum %v", enum)
+		}
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if required, ok := s["required"].([]interface{}); ok {
+			for _, name := range required {
+				if key, ok := name.(string); ok {
+					if _, present := v[key]; !present {
+						fail("missing required property %q", key)
+					}
+				}
+			}
+		}
+
+		properties, _ := s["properties"].(map[string]interface{})
+		keys := make([]string, 0, len(v))
+
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if sub, ok := properties[key].(map[string]interface{}); ok {
+				jsonSchema(sub).check(v[key], path+"."+key, violations)
+				continue
+			}
+
+			switch additional := s["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					fail("unexpected property %q", key)
+				}
+			case map[string]interface{}:
+				jsonSchema(additional).check(v[key], path+"."+key, violations)
+			}
+		}
+	case []interface{}:
+		if n, ok := s["minItems"].(float64); ok && float64(len(v)) < n {
+			fail("expected at least %v items, got %d", n, len(v))
+		}
+
+		if n, ok := s["maxItems"].(float64); ok && float64(len(v)) > n {
+			fail("expected at most %v items, got %d", n, len(v))
+		}
+
+		if items, ok := s["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				jsonSchema(items).check(item, fmt.Sprintf("%s[%d]", path, i), violations)
+			}
+		}
+	case string:
+		length := float64(utf8.RuneCountInString(v))
+
+		if n, ok := s["minLength"].(float64); ok && length < n {
+			fail("expected at least %v characters", n)
+		}
+
+		if n, ok := s["maxLength"].(float64); ok && length > n {
+			fail("expected at most %v characters", n)
+		}
+
+		if pattern, ok := s["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(v) {
+				fail("does not match pattern %q", pattern)
+			}
+		}
+	case float64:
+		if n, ok := s["minimum"].(float64); ok && v < n {
+			fail("expected at least %v, got %v", n, v)
+		}
+
+		if n, ok := s["maximum"].(float64); ok && v > n {
+			fail("expected at most %v, got %v", n, v)
+		}
+	}
+}
+
+func jsonType(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func matchesType(v interface{}, t interface{}) bool {
+	types, ok := t.([]interface{})
+
+	if !ok {
+		types = []interface{}{t}
+	}
+
+	actual := jsonType(v)
+
+	for _, want := range types {
+		if want == actual || (want == "number" && actual == "integer") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetJSONSchema makes the request check its JSON body against schema, in
+// SetJSONBody and again before it is sent, so malformed payloads are
+// caught before any network call. The violations are reported in a
+// *SchemaError.
+func (r *Request) SetJSONSchema(schema []byte) error {
+	s, err := parseJSONSchema(schema)
+
+	if err != nil {
+		return err
+	}
+
+	r.schema = s
+
+	return nil
+}
+
+// SetResponseJSONSchema makes the buffered helpers check 2xx response
+// bodies against schema, returning a *SchemaError for contract violations.
+// Checks only run on clients with ValidateResponseSchemas enabled, so they
+// can stay in the code without costing anything in production.
+func (r *Request) SetResponseJSONSchema(schema []byte) error {
+	s, err := parseJSONSchema(schema)
+
+	if err != nil {
+		return err
+	}
+
+	r.responseSchema = s
+
+	return nil
+}
+
+// ValidateResponseSchemas turns on the checks set up with
+// SetResponseJSONSchema.
+func (c *Client) ValidateResponseSchemas(enable bool) {
+	c.responseSchemas = enable
+}
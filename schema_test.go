@@ -0,0 +1,99 @@
+package gors
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const userSchema = `{
+	"type": "object",
+	"required": ["name", "age"],
+	"properties": {
+		"name": {"type": "string"},
+		"age": {"type": "integer", "minimum": 0},
+		"tags": {"type": "array", "items": {"type": "string"}}
+	},
+	"additionalProperties": false
+}`
+
+func TestSetJSONSchema(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer srv.Close()
+
+	r := NewClient(srv.URL).NewRequest(POST, "/users")
+
+	if err := r.SetJSONSchema([]byte(userSchema)); err != nil {
+		t.Fatal(err)
+	}
+
+	err := r.SetJSONBody(map[string]interface{}{"age": -1.5, "tags": []interface{}{"a", 1}, "extra": true})
+
+	var schemaErr *SchemaError
+
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("got %v, want a *SchemaError", err)
+	}
+
+	// Missing name, fractional age, non-string tag and unknown property.
+	if len(schemaErr.Violations) != 4 {
+		t.Fatalf("got violations %q, want 4", schemaErr.Violations)
+	}
+
+	if !strings.Contains(err.Error(), "name") {
+		t.Fatalf("error %q doesn't name the missing field", err)
+	}
+
+	r.SetBody([]byte(`{"age":3}`))
+
+	if _, err := r.Send(); !errors.As(err, &schemaErr) {
+		t.Fatalf("got %v, want a *SchemaError before sending", err)
+	}
+
+	if calls != 0 {
+		t.Fatal("an invalid body reached the server")
+	}
+
+	if err := r.SetJSONBody(map[string]interface{}{"name": "gors", "age": 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.Send(); err != nil || calls != 1 {
+		t.Fatalf("got %v after %d calls for a valid body", err, calls)
+	}
+}
+
+func TestSetResponseJSONSchema(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"gors"}`))
+	}))
+	defer srv.Close()
+
+	schema := []byte(`{"type":"object","required":["id","name"]}`)
+	c := NewClient(srv.URL)
+
+	r := c.NewRequest(GET, "/")
+	r.SetResponseJSONSchema(schema)
+
+	if _, err := SendWithJSONResponse[map[string]string](r); err != nil {
+		t.Fatalf("got %v with validation turned off", err)
+	}
+
+	c.ValidateResponseSchemas(true)
+
+	r = c.NewRequest(GET, "/")
+	r.SetResponseJSONSchema(schema)
+
+	_, err := SendWithJSONResponse[map[string]string](r)
+
+	var schemaErr *SchemaError
+
+	if !errors.As(err, &schemaErr) || !strings.Contains(err.Error(), `"id"`) {
+		t.Fatalf("got %v, want a *SchemaError for the missing id", err)
+	}
+}
@@ -0,0 +1,62 @@
+package gors
+
+import "sync"
+
+// sharedState holds what may change while the client is in use: default
+// headers, the in-flight request count, the backend rotation, the response
+// memo and the rate limits. Copies of a Client share it, so e.g. a token
+// update reaches every copy.
+type sharedState struct {
+	// The atomically accessed 64-bit fields come first to keep them aligned
+	// on 32-bit platforms.
+	inFlight    int64
+	nextBackend uint64
+	closed      int32
+
+	mu      sync.RWMutex
+	headers map[string]string
+
+	memo    *responseMemo
+	limiter map[string]*tokenBucket
+}
+
+// AddDefaultHeader sets a default header. Unlike SetDefaultHeaders it may be
+// called while other goroutines create and send requests, e.g. to rotate a
+// token; this requires a client created with NewClient.
+func (c *Client) AddDefaultHeader(key, value string) {
+	if c.shared == nil {
+		c.shared = &sharedState{}
+	}
+
+	c.shared.mu.Lock()
+	defer c.shared.mu.Unlock()
+
+	headers := copyMap(c.shared.headers)
+	headers[key] = value
+	c.shared.headers = headers
+}
+
+// SetBearerToken sets the Authorization header of all requests created from
+// now on. It is safe for concurrent use like AddDefaultHeader.
+func (c *Client) SetBearerToken(token string) {
+	c.AddDefaultHeader("Authorization", "Bearer "+token)
+}
+
+// defaultHeaders returns DefaultHeaders together with the headers added by
+// AddDefaultHeader, which take precedence.
+func (c Client) defaultHeaders() map[string]string {
+	headers := copyMap(c.DefaultHeaders)
+
+	if c.shared == nil {
+		return headers
+	}
+
+	c.shared.mu.RLock()
+	defer c.shared.mu.RUnlock()
+
+	for k, v := range c.shared.headers {
+		headers[k] = v
+	}
+
+	return headers
+}
@@ -0,0 +1,58 @@
+package gors
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// Run with -race to check default headers can be changed while requests
+// are created.
+func TestConcurrentDefaultHeaders(t *testing.T) {
+	c := NewClient("http://example.com")
+	c.SetDefaultHeaders(map[string]string{"Accept": "application/json"})
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				c.SetBearerToken(fmt.Sprint(i, j))
+				c.AddDefaultHeader(fmt.Sprint("X-Worker-", i), "on")
+			}
+		}(i)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				r := c.NewRequest(GET, "/")
+				r.Reset()
+				c.GetDefaultHeaders()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	headers := c.NewRequest(GET, "/").GetHeaders()
+
+	if headers["Accept"] != "application/json" || headers["X-Worker-7"] != "on" {
+		t.Fatalf("got headers %v", headers)
+	}
+}
+
+func TestCopiesShareDefaultHeaders(t *testing.T) {
+	c := NewClient("http://example.com")
+	copied := c
+
+	c.SetBearerToken("new")
+
+	if got := copied.NewRequest(GET, "/").GetHeaders()["Authorization"]; got != "Bearer new" {
+		t.Fatalf("copy got Authorization %q, want the updated token", got)
+	}
+}
@@ -0,0 +1,99 @@
+package gors
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// StreamJSONArray decodes a JSON array response one element at a time and
+// calls fn for each, without holding the whole array in memory. Returning
+// an error from fn stops the stream.
+func StreamJSONArray[T any](r *Request, fn func(T) error) (*http.Response, error) {
+	return r.Stream(func(body io.Reader) error {
+		d := json.NewDecoder(body)
+		tok, err := d.Token()
+
+		if err != nil {
+			return err
+		}
+
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return fmt.Errorf("expected JSON array, got %v", tok)
+		}
+
+		for d.More() {
+			var raw json.RawMessage
+
+			if err := d.Decode(&raw); err != nil {
+				return err
+			}
+
+			var item T
+
+			if err := r.decodeJSON(raw, &item); err != nil {
+				return err
+			}
+
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+
+		_, err = d.Token()
+
+		return err
+	})
+}
+
+// StreamNDJSON decodes a newline-delimited JSON response line by line and
+// calls fn for each value. Blank lines are skipped.
+func StreamNDJSON[T any](r *Request, fn func(T) error) (*http.Response, error) {
+	return r.Stream(func(body io.Reader) error {
+		scanner := bufio.NewScanner(body)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+
+			if len(line) == 0 {
+				continue
+			}
+
+			var item T
+
+			if err := r.decodeJSON(line, &item); err != nil {
+				return err
+			}
+
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+
+		return scanner.Err()
+	})
+}
+
+// SendJSONReader sends the request and returns a decoder reading straight
+// from the response body, which is decompressed but not read yet, so large
+// documents can be decoded piece by piece. The client's key style and JSON
+// codec don't apply. The caller must close res.Body, unless the status is
+// one the client stops streams on, which gives a *StatusError instead.
+func SendJSONReader(r *Request) (*json.Decoder, *http.Response, error) {
+	res, err := r.do(context.Background())
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := r.stopStream(res); err != nil {
+		return nil, res, err
+	}
+
+	return json.NewDecoder(r.limitDownload(res.Body)), res, nil
+}
@@ -0,0 +1,136 @@
+package gors
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStopStreamOn(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("forbidden"))
+		w.(http.Flusher).Flush()
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	c := NewClient(srv.URL)
+	c.StopStreamOn(http.StatusForbidden)
+
+	called := false
+	start := time.Now()
+	_, err := c.NewRequest(GET, "/").Stream(func(io.Reader) error {
+		called = true
+		return nil
+	})
+
+	var statusErr *StatusError
+
+	if !errors.As(err, &statusErr) || statusErr.Code != http.StatusForbidden {
+		t.Fatalf("got error %v, want a *StatusError with code 403", err)
+	}
+
+	if called {
+		t.Fatal("stream callback was called for a stopped status")
+	}
+
+	if d := time.Since(start); d > time.Second {
+		t.Fatalf("Stream took %v to return", d)
+	}
+}
+
+func TestStreamJSONArray(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("["))
+
+		for i := 0; i < 1000; i++ {
+			if i > 0 {
+				w.Write([]byte(","))
+			}
+
+			fmt.Fprintf(w, `{"n":%d}`, i)
+
+			if i%100 == 0 {
+				w.(http.Flusher).Flush()
+			}
+		}
+
+		w.Write([]byte("]"))
+	}))
+	defer srv.Close()
+
+	type element struct {
+		N int `json:"n"`
+	}
+
+	next := 0
+	_, err := StreamJSONArray(NewClient(srv.URL).NewRequest(GET, "/"), func(e element) error {
+		if e.N != next {
+			t.Fatalf("got element %d, want %d", e.N, next)
+		}
+
+		next++
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if next != 1000 {
+		t.Fatalf("got %d elements, want 1000", next)
+	}
+}
+
+func TestStreamNDJSONGzip(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+
+		for i := 0; i < 5; i++ {
+			fmt.Fprintf(zw, "{\"n\":%d}\n", i)
+			zw.Flush()
+			w.(http.Flusher).Flush()
+		}
+
+		zw.Close()
+	}))
+	defer srv.Close()
+
+	// Setting Accept-Encoding by hand turns off net/http's decompression,
+	// so the stream reaches gors compressed.
+	r := NewClient(srv.URL).NewRequest(GET, "/")
+	r.SetHeader("Accept-Encoding", "gzip")
+
+	type line struct {
+		N int `json:"n"`
+	}
+
+	next := 0
+	_, err := StreamNDJSON(r, func(l line) error {
+		if l.N != next {
+			t.Fatalf("got line %d, want %d", l.N, next)
+		}
+
+		next++
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if next != 5 {
+		t.Fatalf("got %d lines, want 5", next)
+	}
+}
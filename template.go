@@ -0,0 +1,46 @@
+package gors
+
+import "fmt"
+
+// RequestTemplate holds the method, path, headers and query shared by a
+// family of requests. Build returns a fresh request each time, so requests
+// built from the same template can be changed independently.
+type RequestTemplate struct {
+	client  Client
+	Method  string
+	Path    string
+	Query   map[string]string
+	Headers map[string]string
+}
+
+func (c Client) Template(method string, path string) *RequestTemplate {
+	return &RequestTemplate{
+		client:  c,
+		Method:  method,
+		Path:    path,
+		Query:   make(map[string]string),
+		Headers: make(map[string]string),
+	}
+}
+
+func (t *RequestTemplate) SetHeader(key string, value interface{}) {
+	t.Headers[key] = fmt.Sprintf("%v", value)
+}
+
+func (t *RequestTemplate) SetQuery(key string, value interface{}) {
+	t.Query[key] = fmt.Sprintf("%v", value)
+}
+
+func (t *RequestTemplate) Build() *Request {
+	request := t.client.NewRequest(t.Method, t.Path)
+
+	for k, v := range t.Headers {
+		request.SetHeader(k, v)
+	}
+
+	for k, v := range t.Query {
+		request.SetQuery(k, v)
+	}
+
+	return request
+}
@@ -0,0 +1,38 @@
+package gors
+
+import (
+	"testing"
+)
+
+func TestTemplateBuildsIndependentRequests(t *testing.T) {
+	tmpl := NewClient("http://example.com").Template(GET, "/items")
+	tmpl.SetHeader("X-Tenant", "acme")
+	tmpl.SetQuery("limit", 10)
+
+	a := tmpl.Build()
+	b := tmpl.Build()
+
+	a.SetHeader("X-Tenant", "other")
+	a.SetQuery("limit", 20)
+	a.SetQuery("page", 2)
+
+	if got := b.Headers["X-Tenant"]; got != "acme" {
+		t.Errorf("b header changed to %q", got)
+	}
+
+	if got := b.Query["limit"]; got != "10" {
+		t.Errorf("b query changed to %q", got)
+	}
+
+	if _, ok := b.Query["page"]; ok {
+		t.Error("b got a query parameter set on a")
+	}
+
+	if tmpl.Headers["X-Tenant"] != "acme" || tmpl.Query["limit"] != "10" || len(tmpl.Query) != 1 {
+		t.Errorf("template changed: %v %v", tmpl.Headers, tmpl.Query)
+	}
+
+	if a.Method != GET || a.Path != "/items" {
+		t.Errorf("got %s %s", a.Method, a.Path)
+	}
+}
@@ -0,0 +1,105 @@
+package gors
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// ErrTimeout is matched by errors of requests that ran out of time, from
+// the request timeout or a context deadline. A request whose context was
+// canceled fails with context.Canceled instead.
+var ErrTimeout = errors.New("request timed out")
+
+// timeoutError marks a timeout while keeping the original error, so both
+// errors.Is(err, ErrTimeout) and e.g. errors.Is(err,
+// context.DeadlineExceeded) hold.
+type timeoutError struct {
+	err error
+}
+
+func (e *timeoutError) Error() string   { return e.err.Error() }
+func (e *timeoutError) Unwrap() error   { return e.err }
+func (e *timeoutError) Timeout() bool   { return true }
+func (e *timeoutError) Temporary() bool { return true }
+
+func (e *timeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
+// classifyTimeout wraps err in a timeoutError if it is a timeout. A
+// cancellation is left alone, even if the transport also reports it as a
+// timeout.
+func classifyTimeout(err error) error {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, ErrTimeout) {
+		return err
+	}
+
+	var netErr net.Error
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout() {
+		return &timeoutError{err: err}
+	}
+
+	return err
+}
+
+// SetDefaultTimeout sets the Timeout of requests created by the client,
+// replacing the built-in 10 seconds.
+func (c *Client) SetDefaultTimeout(d time.Duration) {
+	c.timeout = &d
+}
+
+// SetDefaultTimeoutString is SetDefaultTimeout with a duration string such
+// as "5s" or "1m30s", for configuration files.
+func (c *Client) SetDefaultTimeoutString(s string) error {
+	d, err := time.ParseDuration(s)
+
+	if err != nil {
+		return err
+	}
+
+	c.SetDefaultTimeout(d)
+
+	return nil
+}
+
+// SetTimeoutString sets Timeout from a duration string such as "5s".
+func (r *Request) SetTimeoutString(s string) error {
+	d, err := time.ParseDuration(s)
+
+	if err != nil {
+		return err
+	}
+
+	r.Timeout = d
+
+	return nil
+}
+
+func (c Client) requestTimeout() time.Duration {
+	if c.timeout != nil {
+		return *c.timeout
+	}
+
+	return defaultTimeout
+}
+
+// SetZeroTimeoutMeansUnlimited sets how a zero Request.Timeout is treated:
+// as no limit, which is the default, or as the built-in 10 seconds, so a
+// timeout left unset can't make a request hang.
+func (c *Client) SetZeroTimeoutMeansUnlimited(unlimited bool) {
+	c.zeroTimeoutDefault = !unlimited
+}
+
+// timeout returns the timeout the request is sent with; zero means none.
+func (r *Request) timeout() time.Duration {
+	if r.Timeout == 0 && r.client.zeroTimeoutDefault {
+		return defaultTimeout
+	}
+
+	return r.Timeout
+}
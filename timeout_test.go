@@ -0,0 +1,170 @@
+package gors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeadline(t *testing.T) {
+	r := NewClient("http://example.com").NewRequest(GET, "/")
+	r.Timeout = 5 * time.Second
+
+	before := time.Now()
+	deadline, ok := r.Deadline()
+	after := time.Now()
+
+	if !ok {
+		t.Fatal("got no deadline for a request with a timeout")
+	}
+
+	if deadline.Before(before.Add(r.Timeout)) || deadline.After(after.Add(r.Timeout)) {
+		t.Fatalf("got deadline %v, want about %v", deadline, before.Add(r.Timeout))
+	}
+
+	r.Timeout = 0
+
+	if _, ok := r.Deadline(); ok {
+		t.Fatal("got a deadline for a request without timeout")
+	}
+}
+
+func TestSetTimeoutString(t *testing.T) {
+	c := NewClient("http://example.com")
+
+	if err := c.SetDefaultTimeoutString("1m30s"); err != nil {
+		t.Fatal(err)
+	}
+
+	r := c.NewRequest(GET, "/")
+
+	if r.Timeout != 90*time.Second {
+		t.Fatalf("got default timeout %v, want 1m30s", r.Timeout)
+	}
+
+	if err := r.SetTimeoutString("5s"); err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Timeout != 5*time.Second {
+		t.Fatalf("got timeout %v, want 5s", r.Timeout)
+	}
+
+	if err := r.SetTimeoutString("five seconds"); err == nil {
+		t.Fatal("expected an error for an invalid duration")
+	}
+
+	if r.Timeout != 5*time.Second {
+		t.Fatalf("an invalid duration changed the timeout to %v", r.Timeout)
+	}
+
+	if err := c.SetDefaultTimeoutString("10"); err == nil {
+		t.Fatal("expected an error for a duration without unit")
+	}
+}
+
+func TestSetZeroTimeoutMeansUnlimited(t *testing.T) {
+	c := NewClient("http://example.com")
+	r := c.NewRequest(GET, "/")
+
+	if r.Timeout != 10*time.Second {
+		t.Fatalf("got default timeout %v, want 10s", r.Timeout)
+	}
+
+	r.Timeout = 0
+
+	if _, ok := r.Deadline(); ok {
+		t.Fatal("a zero timeout got a deadline, want it unlimited by default")
+	}
+
+	c.SetZeroTimeoutMeansUnlimited(false)
+	r = c.NewRequest(GET, "/")
+	r.Timeout = 0
+
+	deadline, ok := r.Deadline()
+
+	if !ok {
+		t.Fatal("a zero timeout got no deadline, want the default")
+	}
+
+	if remaining := time.Until(deadline); remaining < 9*time.Second || remaining > 10*time.Second {
+		t.Fatalf("got deadline %v away, want about 10s", remaining)
+	}
+}
+
+func TestCancellationIsNotTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err := c.NewRequest(GET, "/").SendWithCtx(ctx)
+
+	if !errors.Is(err, context.Canceled) || errors.Is(err, ErrTimeout) {
+		t.Fatalf("cancel: got %v, want context.Canceled and not ErrTimeout", err)
+	}
+
+	r := c.NewRequest(GET, "/")
+	r.Timeout = 50 * time.Millisecond
+
+	_, err = r.Send()
+
+	if !errors.Is(err, ErrTimeout) || errors.Is(err, context.Canceled) {
+		t.Fatalf("timeout: got %v, want ErrTimeout and not context.Canceled", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = c.NewRequest(GET, "/").SendWithCtx(ctx)
+
+	if !errors.Is(err, ErrTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("deadline: got %v, want ErrTimeout and context.DeadlineExceeded", err)
+	}
+}
+
+func TestDeadlineDuringWaitsIsTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetRetryPolicy(RetryPolicy{MaxRetries: 1, Backoff: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.NewRequest(GET, "/").SendWithCtx(ctx)
+
+	if !errors.Is(err, ErrTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("backoff: got %v, want ErrTimeout and context.DeadlineExceeded", err)
+	}
+
+	c = NewClient(srv.URL)
+	c.SetRateLimitBuckets(map[string]RateLimitConfig{DefaultRateLimitBucket: {Rate: 0.1, Burst: 1}})
+
+	if _, err := c.NewRequest(GET, "/").Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = c.NewRequest(GET, "/").SendWithCtx(ctx)
+
+	if !errors.Is(err, ErrTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("rate limit: got %v, want ErrTimeout and context.DeadlineExceeded", err)
+	}
+}
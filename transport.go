@@ -0,0 +1,178 @@
+package gors
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ensureTransport returns the client's own transport, creating it from
+// http.DefaultTransport the first time a transport setting is changed.
+// Requests made before then use http.DefaultTransport.
+func (c *Client) ensureTransport() *http.Transport {
+	if c.transport == nil {
+		c.transport = cloneTransport(http.DefaultTransport)
+	}
+
+	return c.transport
+}
+
+// cloneTransport returns a copy of rt, or a new transport with the same
+// settings as the stock http.DefaultTransport if rt isn't an
+// *http.Transport, e.g. when DefaultTransport was replaced by a wrapper.
+func cloneTransport(rt http.RoundTripper) *http.Transport {
+	if t, ok := rt.(*http.Transport); ok {
+		return t.Clone()
+	}
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// transport returns the transport the request is sent with. Requests with
+// settings that only apply to them get a copy of the client's transport,
+// without keep-alives so the copy doesn't hold on to idle connections.
+func (r *Request) transport() http.RoundTripper {
+	var base http.RoundTripper = http.DefaultTransport
+
+	if r.client.transport != nil {
+		base = r.client.transport
+	}
+
+	if r.sni == "" && !r.noExpectContinue && r.clientCert == nil {
+		return base
+	}
+
+	t := cloneTransport(base)
+	t.DisableKeepAlives = true
+
+	if r.sni != "" {
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+
+		t.TLSClientConfig.ServerName = r.sni
+	}
+
+	if r.noExpectContinue {
+		t.ExpectContinueTimeout = 0
+	}
+
+	if r.clientCert != nil {
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+
+		t.TLSClientConfig.Certificates = []tls.Certificate{*r.clientCert}
+	}
+
+	return t
+}
+
+// DisableExpectContinue makes sure the request is sent without an
+// Expect: 100-continue header, for servers that can't handle it, and
+// without waiting for a 100 Continue response.
+func (r *Request) DisableExpectContinue(disable bool) {
+	r.noExpectContinue = disable
+}
+
+// SetSNI sends the request with serverName as TLS server name and Host
+// header, while connecting to the host in the URL. This allows reaching a
+// specific backend by IP address, e.g. for canary testing.
+func (r *Request) SetSNI(serverName string) {
+	r.sni = serverName
+}
+
+// SetClientCertificate sends the request with cert as TLS client
+// certificate, for servers requiring mutual TLS, instead of those set with
+// SetClientCertificates.
+func (r *Request) SetClientCertificate(cert tls.Certificate) {
+	r.clientCert = &cert
+}
+
+// SetClientCertificates sets the TLS client certificates the client
+// presents to servers requiring mutual TLS.
+func (c *Client) SetClientCertificates(certs ...tls.Certificate) {
+	transport := c.ensureTransport()
+
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	} else {
+		transport.TLSClientConfig = transport.TLSClientConfig.Clone()
+	}
+
+	transport.TLSClientConfig.Certificates = certs
+}
+
+// SetMaxConnsPerHost bounds the number of connections to a single host.
+// Requests beyond the limit wait for a connection to become free.
+func (c *Client) SetMaxConnsPerHost(n int) {
+	c.ensureTransport().MaxConnsPerHost = n
+}
+
+// SetMaxResponseHeaderBytes limits the size of the response headers a server
+// may send; larger responses fail with an error.
+func (c *Client) SetMaxResponseHeaderBytes(n int64) {
+	c.ensureTransport().MaxResponseHeaderBytes = n
+}
+
+// SetDialContext makes the client open connections with dial, e.g. to
+// inject latency or failures in tests, or to connect through in-memory
+// pipes. A Dial timeout set with SetTimeouts afterwards bounds each call
+// through its context.
+func (c *Client) SetDialContext(dial func(ctx context.Context, network, addr string) (net.Conn, error)) {
+	c.dialContext = dial
+	c.ensureTransport().DialContext = dial
+}
+
+// Timeouts configures the individual phases of a connection. Zero fields
+// leave the corresponding transport setting unchanged.
+type Timeouts struct {
+	Dial           time.Duration
+	TLSHandshake   time.Duration
+	ResponseHeader time.Duration
+	Idle           time.Duration
+}
+
+// SetTimeouts applies fine-grained timeouts to the client's transport. They
+// work alongside Request.Timeout, which bounds the whole request.
+func (c *Client) SetTimeouts(t Timeouts) {
+	transport := c.ensureTransport()
+
+	if t.Dial > 0 && c.dialContext != nil {
+		dial, timeout := c.dialContext, t.Dial
+
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			return dial(ctx, network, addr)
+		}
+	} else if t.Dial > 0 {
+		dialer := &net.Dialer{Timeout: t.Dial, KeepAlive: 30 * time.Second}
+		transport.DialContext = dialer.DialContext
+	}
+
+	if t.TLSHandshake > 0 {
+		transport.TLSHandshakeTimeout = t.TLSHandshake
+	}
+
+	if t.ResponseHeader > 0 {
+		transport.ResponseHeaderTimeout = t.ResponseHeader
+	}
+
+	if t.Idle > 0 {
+		transport.IdleConnTimeout = t.Idle
+	}
+}
@@ -0,0 +1,400 @@
+package gors
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSetMaxConnsPerHost(t *testing.T) {
+	var open, peak int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		switch state {
+		case http.StateNew:
+			n := atomic.AddInt32(&open, 1)
+
+			for {
+				p := atomic.LoadInt32(&peak)
+
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+		case http.StateClosed, http.StateHijacked:
+			atomic.AddInt32(&open, -1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetMaxConnsPerHost(2)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := c.NewRequest(GET, "/").Send(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if p := atomic.LoadInt32(&peak); p > 2 {
+		t.Fatalf("server saw %d simultaneous connections, want at most 2", p)
+	}
+}
+
+type wrappedTransport struct {
+	next http.RoundTripper
+}
+
+func (w wrappedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return w.next.RoundTrip(req)
+}
+
+func TestTransportSettingsWithWrappedDefaultTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	orig := http.DefaultTransport
+	http.DefaultTransport = wrappedTransport{next: orig}
+	defer func() { http.DefaultTransport = orig }()
+
+	c := NewClient(srv.URL)
+	c.SetMaxConnsPerHost(2)
+
+	if _, err := c.NewRequest(GET, "/").Send(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSetMaxResponseHeaderBytes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Big", strings.Repeat("a", 10000))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	if _, err := c.NewRequest(GET, "/").Send(); err != nil {
+		t.Fatalf("default limit: %v", err)
+	}
+
+	c.SetMaxResponseHeaderBytes(1024)
+
+	_, err := c.NewRequest(GET, "/").Send()
+
+	if err == nil || !strings.Contains(err.Error(), "header") {
+		t.Fatalf("got error %v, want one about the headers being too large", err)
+	}
+}
+
+func TestSetTimeouts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	var remaining time.Duration
+
+	c := NewClient(srv.URL)
+	c.SetDialContext(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if deadline, ok := ctx.Deadline(); ok {
+			remaining = time.Until(deadline)
+		}
+
+		var d net.Dialer
+
+		return d.DialContext(ctx, network, addr)
+	})
+	c.SetTimeouts(Timeouts{
+		Dial:           time.Second,
+		TLSHandshake:   2 * time.Second,
+		ResponseHeader: 3 * time.Second,
+		Idle:           4 * time.Second,
+	})
+
+	transport := c.transport
+
+	if transport.TLSHandshakeTimeout != 2*time.Second {
+		t.Errorf("got TLSHandshakeTimeout %v, want 2s", transport.TLSHandshakeTimeout)
+	}
+
+	if transport.ResponseHeaderTimeout != 3*time.Second {
+		t.Errorf("got ResponseHeaderTimeout %v, want 3s", transport.ResponseHeaderTimeout)
+	}
+
+	if transport.IdleConnTimeout != 4*time.Second {
+		t.Errorf("got IdleConnTimeout %v, want 4s", transport.IdleConnTimeout)
+	}
+
+	if _, err := c.NewRequest(GET, "/").Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if remaining <= 0 || remaining > time.Second {
+		t.Errorf("dial deadline was %v away, want at most 1s", remaining)
+	}
+}
+
+func TestSetSNI(t *testing.T) {
+	// The test certificate is valid for example.com and 127.0.0.1; the
+	// server is reached through its loopback address either way.
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.TLS.ServerName + " " + r.Host))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.ensureTransport().TLSClientConfig = srv.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+
+	r := c.NewRequest(GET, "/")
+	r.SetSNI("example.com")
+
+	res, err := r.Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(res.Body) != "example.com example.com" {
+		t.Fatalf("server saw SNI and Host %q, want example.com for both", res.Body)
+	}
+
+	r = c.NewRequest(GET, "/")
+	r.SetSNI("wrong.test")
+
+	if _, err := r.Send(); err == nil {
+		t.Fatal("expected certificate verification to fail for a name the certificate doesn't cover")
+	}
+}
+
+func TestSetSNIWithWrappedDefaultTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Host))
+	}))
+	defer srv.Close()
+
+	orig := http.DefaultTransport
+	http.DefaultTransport = wrappedTransport{next: orig}
+	defer func() { http.DefaultTransport = orig }()
+
+	r := NewClient(srv.URL).NewRequest(GET, "/")
+	r.SetSNI("example.com")
+
+	res, err := r.Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(res.Body) != "example.com" {
+		t.Fatalf("got Host %q, want example.com", res.Body)
+	}
+}
+
+func TestDisableExpectContinue(t *testing.T) {
+	var expect []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect = append(expect, r.Header.Get("Expect"))
+		io.Copy(io.Discard, r.Body)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.SetDefaultHeaders(map[string]string{"Expect": "100-continue"})
+	body := bytes.Repeat([]byte("x"), 64<<10)
+
+	r := c.NewRequest(PUT, "/upload")
+	r.SetBody(body)
+
+	if _, err := r.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	r = c.NewRequest(PUT, "/upload")
+	r.SetBody(body)
+	r.DisableExpectContinue(true)
+
+	if _, err := r.Send(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(expect) != 2 || expect[0] != "100-continue" || expect[1] != "" {
+		t.Fatalf("got Expect headers %q, want one only without the flag", expect)
+	}
+}
+
+// pipeListener is a net.Listener whose connections are in-memory pipes
+// created by dial.
+type pipeListener struct {
+	conns  chan net.Conn
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newPipeListener() *pipeListener {
+	return &pipeListener{conns: make(chan net.Conn), closed: make(chan struct{})}
+}
+
+func (l *pipeListener) dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	client, server := net.Pipe()
+
+	select {
+	case l.conns <- server:
+		return client, nil
+	case <-l.closed:
+		return nil, errors.New("listener closed")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (l *pipeListener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *pipeListener) Close() error {
+	l.once.Do(func() { close(l.closed) })
+	return nil
+}
+
+func (l *pipeListener) Addr() net.Addr {
+	return &net.UnixAddr{Name: "pipe", Net: "pipe"}
+}
+
+func TestSetDialContext(t *testing.T) {
+	l := newPipeListener()
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Host + r.URL.Path))
+	})}
+	go srv.Serve(l)
+	defer srv.Close()
+
+	var dialed []string
+
+	c := NewClient("http://backend.invalid")
+	c.SetDialContext(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = append(dialed, addr)
+		return l.dial(ctx, network, addr)
+	})
+
+	res, err := c.NewRequest(GET, "/ping").Send()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(res.Body) != "backend.invalid/ping" {
+		t.Fatalf("got %q", res.Body)
+	}
+
+	if len(dialed) != 1 || dialed[0] != "backend.invalid:80" {
+		t.Fatalf("dialed %v, want backend.invalid:80 once", dialed)
+	}
+}
+
+// clientCert returns a self-signed client certificate and a pool trusting
+// it.
+func clientCert(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := x509.ParseCertificate(der)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(parsed)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pool
+}
+
+func TestClientCertificates(t *testing.T) {
+	cert, pool := clientCert(t)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool}
+	srv.StartTLS()
+	defer srv.Close()
+
+	trustServer := Config{TLS: srv.Client().Transport.(*http.Transport).TLSClientConfig}
+
+	c := NewClient(srv.URL)
+
+	if err := c.Configure(trustServer); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.NewRequest(GET, "/").Send(); err == nil {
+		t.Fatal("request without a certificate succeeded")
+	}
+
+	r := c.NewRequest(GET, "/")
+	r.SetClientCertificate(cert)
+
+	if _, err := r.Send(); err != nil {
+		t.Fatalf("request certificate: %v", err)
+	}
+
+	withCert := NewClient(srv.URL)
+
+	if err := withCert.Configure(trustServer); err != nil {
+		t.Fatal(err)
+	}
+
+	withCert.SetClientCertificates(cert)
+
+	if _, err := withCert.NewRequest(GET, "/").Send(); err != nil {
+		t.Fatalf("client certificate: %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package gors
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// Warmup opens up to n connections to the base URL host ahead of a burst of
+// traffic by sending n concurrent HEAD requests to the base URL. The
+// transport is made to keep at least n idle connections so they stay
+// pooled. The response status is ignored; only transport errors are
+// returned.
+func (c *Client) Warmup(ctx context.Context, n int) error {
+	transport := c.ensureTransport()
+
+	if transport.MaxIdleConnsPerHost < n {
+		transport.MaxIdleConnsPerHost = n
+	}
+
+	if transport.MaxIdleConns != 0 && transport.MaxIdleConns < n {
+		transport.MaxIdleConns = n
+	}
+
+	errs := make(chan error, n)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			res, err := c.NewRequest(HEAD, "").do(ctx)
+
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	return <-errs
+}
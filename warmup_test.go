@@ -0,0 +1,47 @@
+package gors
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWarmup(t *testing.T) {
+	srv, conns := newConnCountingServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+
+	if err := c.Warmup(context.Background(), 3); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := atomic.LoadInt32(conns); n != 3 {
+		t.Fatalf("warm-up opened %d connections, want 3", n)
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := c.NewRequest(GET, "/").Send(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if n := atomic.LoadInt32(conns); n != 3 {
+		t.Fatalf("server saw %d connections, want the 3 warm ones reused", n)
+	}
+}